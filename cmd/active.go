@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/pomdtr/webterm/webterm"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdTabActive(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "active",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg := map[string]any{
+				"command": "tab.query",
+				"active":  true,
+			}
+
+			if cmd.Flags().Changed("window") {
+				windowId, _ := cmd.Flags().GetInt("window")
+				msg["windowId"] = windowId
+			} else {
+				msg["lastFocusedWindow"] = true
+			}
+
+			res, err := sendMessage(msg)
+			if err != nil {
+				return err
+			}
+
+			tabs, err := webterm.DecodeTabs(res)
+			if err != nil {
+				return err
+			}
+
+			if len(tabs) == 0 {
+				if windowId, ok := msg["windowId"]; ok {
+					return webterm.NotFoundf("no active tab in window %d", windowId)
+				}
+				return webterm.NotFoundf("no active tab found")
+			}
+			tab := tabs[0]
+
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if jsonOutput {
+				return writeJSON(cmd, newTabOutput(tab))
+			}
+
+			printer.AddField(strconv.Itoa(tab.ID))
+			printer.AddField(tab.Title)
+			printer.AddField(tab.URL)
+			printer.EndRow()
+
+			if err := printer.Render(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int("window", 0, "id of the window, defaults to the focused one")
+
+	return cmd
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/spf13/cobra"
+)
+
+// setMutedAll mutes or unmutes every tab of the browser whose muted state differs, and returns how many changed.
+func setMutedAll(ctx context.Context, muted bool) (int, error) {
+	tabs, err := client.ListAllTabs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return setTabsMuted(ctx, tabs, muted)
+}
+
+// setTabsMuted mutes or unmutes the tabs whose muted state differs, and returns how many changed.
+func setTabsMuted(ctx context.Context, tabs []Tab, muted bool) (int, error) {
+	var tabIds []int
+	for _, tab := range tabs {
+		if tab.MutedInfo.Muted != muted {
+			tabIds = append(tabIds, tab.ID)
+		}
+	}
+
+	if len(tabIds) == 0 {
+		return 0, nil
+	}
+
+	command := "tab.unmute"
+	if muted {
+		command = "tab.mute"
+	}
+
+	if _, err := sendMessage(map[string]any{
+		"command": command,
+		"tabIds":  tabIds,
+	}); err != nil {
+		return 0, err
+	}
+
+	return len(tabIds), nil
+}
+
+func audioState(tab Tab) string {
+	switch {
+	case tab.Audible && tab.MutedInfo.Muted:
+		return "playing, muted"
+	case tab.Audible:
+		return "playing"
+	default:
+		return "muted"
+	}
+}
+
+func NewCmdAudioList(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "list",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tabs, err := client.ListAllTabs(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			audioTabs := make([]Tab, 0)
+			for _, tab := range tabs {
+				if tab.Audible || tab.MutedInfo.Muted {
+					audioTabs = append(audioTabs, tab)
+				}
+			}
+
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if jsonOutput {
+				return writeJSON(cmd, newTabOutputs(audioTabs))
+			}
+
+			for _, tab := range audioTabs {
+				printer.AddField(strconv.Itoa(tab.ID))
+				printer.AddField(audioState(tab))
+				printer.AddField(tab.Title)
+				printer.AddField(tab.URL)
+				printer.EndRow()
+			}
+
+			if err := printer.Render(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("json", false, "output as json")
+
+	return cmd
+}
+
+func NewCmdAudioMuteAll() *cobra.Command {
+	return &cobra.Command{
+		Use:  "mute-all",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			count, err := setMutedAll(cmd.Context(), true)
+			if err != nil {
+				return err
+			}
+
+			printSummary(cmd, "muted", count, 0)
+			return nil
+		},
+	}
+}
+
+func NewCmdAudioUnmuteAll() *cobra.Command {
+	return &cobra.Command{
+		Use:  "unmute-all",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			count, err := setMutedAll(cmd.Context(), false)
+			if err != nil {
+				return err
+			}
+
+			printSummary(cmd, "unmuted", count, 0)
+			return nil
+		},
+	}
+}
+
+func NewCmdAudio(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "audio",
+	}
+
+	cmd.AddCommand(NewCmdAudioList(printer))
+	cmd.AddCommand(NewCmdAudioMuteAll())
+	cmd.AddCommand(NewCmdAudioUnmuteAll())
+
+	return cmd
+}
+
+func NewCmdTabAudio(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "audio",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tabs, err := client.ListAllTabs(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			// muted tabs stay audible, the browser still reports the sound they make
+			var audible []Tab
+			for _, tab := range tabs {
+				if tab.Audible {
+					audible = append(audible, tab)
+				}
+			}
+
+			muteAll, _ := cmd.Flags().GetBool("mute-all")
+			unmuteAll, _ := cmd.Flags().GetBool("unmute-all")
+			if muteAll || unmuteAll {
+				count, err := setTabsMuted(cmd.Context(), audible, muteAll)
+				if err != nil {
+					return err
+				}
+
+				verb := "muted"
+				if unmuteAll {
+					verb = "unmuted"
+				}
+				printSummary(cmd, verb, count, len(audible)-count)
+				return nil
+			}
+
+			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+				return writeJSON(cmd, newTabOutputs(audible))
+			}
+
+			for _, tab := range audible {
+				printer.AddField(strconv.Itoa(tab.ID))
+				printer.AddField(tab.Title)
+				printer.AddField(tab.URL)
+				if tab.MutedInfo.Muted {
+					printer.AddField("muted")
+				} else {
+					printer.AddField("")
+				}
+				printer.EndRow()
+			}
+
+			return printer.Render()
+		},
+	}
+
+	cmd.Flags().Bool("mute-all", false, "mute the tabs playing sound")
+	cmd.Flags().Bool("unmute-all", false, "unmute the tabs playing sound")
+	cmd.MarkFlagsMutuallyExclusive("mute-all", "unmute-all")
+
+	return cmd
+}
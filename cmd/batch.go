@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// batchError is printed in place of the response of a failed message with --continue-on-error.
+type batchError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// sendBatchLine validates a message read by the batch command and returns the compacted response.
+func sendBatchLine(line []byte) ([]byte, error) {
+	var msg struct {
+		Command string `json:"command"`
+	}
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return nil, fmt.Errorf("invalid message: %w", err)
+	}
+
+	if msg.Command == "" {
+		return nil, fmt.Errorf("invalid message: missing command")
+	}
+
+	res, err := sendMessage(json.RawMessage(line))
+	if err != nil {
+		return nil, err
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, res); err != nil {
+		return nil, err
+	}
+
+	return compacted.Bytes(), nil
+}
+
+func NewCmdBatch() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "batch",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
+
+			scanner := bufio.NewScanner(os.Stdin)
+			// responses are small but messages may embed whole scripts
+			scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+			var lineNumber, failed int
+			for scanner.Scan() {
+				lineNumber++
+				line := bytes.TrimSpace(scanner.Bytes())
+				if len(line) == 0 {
+					continue
+				}
+
+				res, err := sendBatchLine(line)
+				if err != nil {
+					if !continueOnError {
+						return fmt.Errorf("line %d: %w", lineNumber, err)
+					}
+
+					failed++
+					res, err = json.Marshal(batchError{Line: lineNumber, Error: err.Error()})
+					if err != nil {
+						return err
+					}
+				}
+
+				fmt.Println(string(res))
+			}
+
+			if err := scanner.Err(); err != nil {
+				return fmt.Errorf("unable to read messages: %w", err)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d messages failed", failed)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("continue-on-error", false, "print an error object for the failed messages instead of stopping")
+
+	return cmd
+}
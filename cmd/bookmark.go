@@ -1,14 +1,77 @@
 package cmd
 
 import (
-	"os"
+	"fmt"
+	"strings"
 
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
 	"github.com/spf13/cobra"
 )
 
-func NewCmdBookmarkList() *cobra.Command {
-	return &cobra.Command{
-		Use: "list",
+type Bookmark struct {
+	Children  []Bookmark `json:"children,omitempty"`
+	DateAdded float64    `json:"dateAdded"`
+	ID        string     `json:"id"`
+	Index     int        `json:"index"`
+	ParentID  string     `json:"parentId"`
+	Title     string     `json:"title"`
+	URL       string     `json:"url,omitempty"`
+}
+
+// flattenBookmarks returns the bookmarks of a tree in order, leaving the folders out.
+func flattenBookmarks(nodes []Bookmark) []Bookmark {
+	var bookmarks []Bookmark
+	for _, node := range nodes {
+		if node.URL != "" {
+			bookmarks = append(bookmarks, node)
+		}
+		bookmarks = append(bookmarks, flattenBookmarks(node.Children)...)
+	}
+
+	return bookmarks
+}
+
+// addBookmarkRows adds a row per node of a tree to the printer, indenting the titles by depth.
+func addBookmarkRows(printer tableprinter.TablePrinter, nodes []Bookmark, depth int) {
+	for _, node := range nodes {
+		// the root of the tree has no title, its children are the top level folders
+		if node.ParentID == "" && node.Title == "" {
+			addBookmarkRows(printer, node.Children, depth)
+			continue
+		}
+
+		printer.AddField(node.ID)
+		printer.AddField(strings.Repeat("  ", depth) + node.Title)
+		printer.AddField(node.URL)
+		printer.EndRow()
+
+		addBookmarkRows(printer, node.Children, depth+1)
+	}
+}
+
+// writeBookmarks prints bookmarks as a table, or as json with --json.
+func writeBookmarks(cmd *cobra.Command, printer tableprinter.TablePrinter, bookmarks []Bookmark) error {
+	if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+		if bookmarks == nil {
+			bookmarks = []Bookmark{}
+		}
+		return writeJSON(cmd, bookmarks)
+	}
+
+	for _, bookmark := range bookmarks {
+		printer.AddField(bookmark.ID)
+		printer.AddField(bookmark.Title)
+		printer.AddField(bookmark.URL)
+		printer.EndRow()
+	}
+
+	return printer.Render()
+}
+
+func NewCmdBookmarkList(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "list",
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			res, err := sendMessage(map[string]string{
 				"command": "bookmark.list",
@@ -17,21 +80,222 @@ func NewCmdBookmarkList() *cobra.Command {
 				return err
 			}
 
-			if _, err := os.Stdout.Write(res); err != nil {
+			var tree []Bookmark
+			if err := decodeResponse(res, &tree); err != nil {
+				return err
+			}
+
+			if flat, _ := cmd.Flags().GetBool("flat"); flat {
+				return writeBookmarks(cmd, printer, flattenBookmarks(tree))
+			}
+
+			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+				return writeJSON(cmd, tree)
+			}
+
+			addBookmarkRows(printer, tree, 0)
+			return printer.Render()
+		},
+	}
+
+	cmd.Flags().Bool("flat", false, "list the bookmarks without their folders")
+	cmd.Flags().Bool("json", false, "output as json")
+
+	return cmd
+}
+
+func NewCmdBookmarkAdd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "add [url] [title]",
+		Args: cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			current, _ := cmd.Flags().GetBool("current")
+			if current == (len(args) > 0) {
+				return fmt.Errorf("either a url or --current is required")
+			}
+
+			msg := map[string]any{
+				"command": "bookmark.create",
+			}
+
+			if current {
+				tab, err := client.GetActiveTab(cmd.Context())
+				if err != nil {
+					return err
+				}
+				msg["url"] = tab.URL
+				msg["title"] = tab.Title
+			} else {
+				url, err := normalizeURL(args[0])
+				if err != nil {
+					return err
+				}
+				msg["url"] = url
+				msg["title"] = url
+				if len(args) > 1 {
+					msg["title"] = args[1]
+				}
+			}
+
+			if folder, _ := cmd.Flags().GetString("folder"); folder != "" {
+				msg["parentId"] = folder
+			}
+
+			res, err := sendMessage(msg)
+			if err != nil {
+				return err
+			}
+
+			var bookmark Bookmark
+			if err := decodeResponse(res, &bookmark); err != nil {
+				return err
+			}
+
+			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+				return writeJSON(cmd, bookmark)
+			}
+
+			fmt.Println(bookmark.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("current", false, "bookmark the active tab")
+	cmd.Flags().String("folder", "", "id of the folder to add the bookmark to, defaults to the other bookmarks")
+	cmd.Flags().Bool("json", false, "output as json")
+
+	return cmd
+}
+
+func NewCmdBookmarkRemove() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "remove <id>...",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, id := range args {
+				if _, err := sendMessage(map[string]any{
+					"command": "bookmark.remove",
+					"id":      id,
+				}); err != nil {
+					return fmt.Errorf("unable to remove bookmark %s: %w", id, err)
+				}
+			}
+
+			cmd.Printf("Removed %s\n", pluralize(len(args), "bookmark"))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func NewCmdBookmarkSearch(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "search <query>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res, err := sendMessage(map[string]any{
+				"command": "bookmark.search",
+				"query":   args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			var bookmarks []Bookmark
+			if err := decodeResponse(res, &bookmarks); err != nil {
+				return err
+			}
+
+			return writeBookmarks(cmd, printer, bookmarks)
+		},
+	}
+
+	cmd.Flags().Bool("json", false, "output as json")
+
+	return cmd
+}
+
+func NewCmdBookmarkMove() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "move <id>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg := map[string]any{
+				"command": "bookmark.move",
+				"id":      args[0],
+			}
+
+			if !cmd.Flags().Changed("folder") && !cmd.Flags().Changed("index") {
+				return fmt.Errorf("either --folder or --index must be set")
+			}
+
+			if cmd.Flags().Changed("folder") {
+				folder, _ := cmd.Flags().GetString("folder")
+				res, err := sendMessage(map[string]any{
+					"command": "bookmark.get",
+					"id":      folder,
+				})
+				if err != nil {
+					return fmt.Errorf("unable to find destination folder %s: %w", folder, err)
+				}
+
+				var bookmarks []Bookmark
+				if err := decodeResponse(res, &bookmarks); err != nil {
+					return err
+				}
+
+				if len(bookmarks) == 0 {
+					return fmt.Errorf("destination folder %s not found", folder)
+				}
+
+				if bookmarks[0].URL != "" {
+					return fmt.Errorf("destination %s is a bookmark, not a folder", folder)
+				}
+
+				msg["parentId"] = folder
+			}
+
+			if cmd.Flags().Changed("index") {
+				index, _ := cmd.Flags().GetInt("index")
+				if index < 0 {
+					return fmt.Errorf("invalid index: %d", index)
+				}
+				msg["index"] = index
+			}
+
+			res, err := sendMessage(msg)
+			if err != nil {
+				return err
+			}
+
+			var bookmark Bookmark
+			if err := decodeResponse(res, &bookmark); err != nil {
 				return err
 			}
 
+			cmd.Printf("Moved bookmark %s to folder %s at index %d\n", bookmark.ID, bookmark.ParentID, bookmark.Index)
 			return nil
 		},
 	}
+
+	cmd.Flags().String("folder", "", "id of the destination folder")
+	cmd.Flags().Int("index", 0, "position of the bookmark in the folder")
+
+	return cmd
 }
 
-func NewCmdBookMark() *cobra.Command {
+func NewCmdBookMark(printer tableprinter.TablePrinter) *cobra.Command {
 	cmd := &cobra.Command{
-		Use: "bookmark",
+		Use:     "bookmark",
+		Aliases: []string{"bookmarks"},
 	}
 
-	cmd.AddCommand(NewCmdBookmarkList())
+	cmd.AddCommand(NewCmdBookmarkList(printer))
+	cmd.AddCommand(NewCmdBookmarkAdd())
+	cmd.AddCommand(NewCmdBookmarkRemove())
+	cmd.AddCommand(NewCmdBookmarkSearch(printer))
+	cmd.AddCommand(NewCmdBookmarkMove())
 
 	return cmd
 }
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/pomdtr/webterm/webterm"
+	"github.com/spf13/cobra"
+)
+
+// browserEnv names the environment variable choosing the browser when --browser is not set.
+const browserEnv = "WEBTERM_BROWSER"
+
+// firefoxExtensionID is the id of the extension declared for firefox in its manifest.
+const firefoxExtensionID = "webterm@pomdtr.me"
+
+// nativeHostDirs are the directories where each browser looks up native messaging manifests.
+var nativeHostDirs = map[string]string{
+	"chrome":  filepath.Join(xdg.DataHome, "Google", "Chrome", "NativeMessagingHosts"),
+	"edge":    filepath.Join(xdg.DataHome, "Microsoft Edge", "NativeMessagingHosts"),
+	"firefox": filepath.Join(xdg.DataHome, "Mozilla", "NativeMessagingHosts"),
+}
+
+// resolveBrowser picks the browser of a command: the --browser flag when set, then WEBTERM_BROWSER, then chrome.
+func resolveBrowser(cmd *cobra.Command) (string, error) {
+	browser, _ := cmd.Flags().GetString("browser")
+	if !cmd.Flags().Changed("browser") {
+		if env := os.Getenv(browserEnv); env != "" {
+			browser = env
+		} else {
+			if config.Browser != "" {
+				browser = config.Browser
+			}
+		}
+	}
+
+	browser = strings.ToLower(browser)
+	if _, err := webterm.BrowserPort(browser); err != nil {
+		return "", err
+	}
+
+	return browser, nil
+}
+
+// browserManifest adapts the native messaging manifest to a browser, starting the host through the given entrypoint.
+func browserManifest(browser string, entrypointPath string) ([]byte, error) {
+	var m map[string]any
+	if err := json.Unmarshal(manifest, &m); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	m["path"] = entrypointPath
+
+	// firefox allows extensions by id instead of origin
+	if browser == "firefox" {
+		delete(m, "allowed_origins")
+		m["allowed_extensions"] = []string{firefoxExtensionID}
+	}
+
+	return json.MarshalIndent(m, "", "    ")
+}
+
+// browserEntrypoint returns the script starting the native host of a browser.
+func browserEntrypoint(browser string) []byte {
+	return []byte(strings.Replace(string(entrypoint), "webterm server", "webterm server --browser "+browser, 1))
+}
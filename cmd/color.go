@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// colorEnabled reports whether tables should be colorized: stdout must be a terminal,
+// and neither --no-color nor the NO_COLOR environment variable must be set.
+func colorEnabled(cmd *cobra.Command) bool {
+	if noColor, _ := cmd.Flags().GetBool("no-color"); noColor {
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	return isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// ansiColor returns a function wrapping its argument in the given sgr escape sequence.
+func ansiColor(code string) func(string) string {
+	return func(s string) string {
+		return "\x1b[" + code + "m" + s + "\x1b[0m"
+	}
+}
+
+var (
+	boldColor   = ansiColor("1")
+	dimColor    = ansiColor("2")
+	yellowColor = ansiColor("33")
+)
+
+// tabRowColor returns the color of the table row of a tab: discarded tabs are dimmed and the active one is bold.
+func tabRowColor(tab Tab) func(string) string {
+	switch {
+	case tab.Discarded:
+		return dimColor
+	case tab.Active:
+		return boldColor
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout bounds the browser lookups done while completing, since the shell waits for them.
+const completionTimeout = 2 * time.Second
+
+// completeTabIDs suggests the ids of open tabs not already given, with their title as description.
+// Errors are ignored, an unreachable browser simply yields no completions.
+func completeTabIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+
+	tabs, err := client.ListAllTabs(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	given := make(map[string]bool)
+	for _, arg := range args {
+		given[arg] = true
+	}
+
+	var completions []string
+	for _, tab := range tabs {
+		id := strconv.Itoa(tab.ID)
+		if given[id] || !strings.HasPrefix(id, toComplete) {
+			continue
+		}
+		completions = append(completions, fmt.Sprintf("%s\t%s", id, tab.Title))
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTabID is completeTabIDs for commands taking a single tab id.
+func completeTabID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return completeTabIDs(cmd, args, toComplete)
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompletionIgnoresConfigAndBrowser(t *testing.T) {
+	dir := isolateConfig(t)
+	if err := os.MkdirAll(filepath.Join(dir, "webterm"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "webterm", "config.toml"), []byte("timeout = [not toml"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		t.Run(shell, func(t *testing.T) {
+			transport := &fakeTransport{}
+			stdout, stderr, err := runCommand(t, transport, "completion", shell)
+			if err != nil {
+				t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+			}
+
+			if !strings.Contains(stdout, "webterm") {
+				t.Errorf("no completion script printed, got %q", stdout)
+			}
+			if len(transport.sent) > 0 {
+				t.Errorf("sent commands %v, want none", transport.commands())
+			}
+		})
+	}
+}
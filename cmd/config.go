@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/adrg/xdg"
+	"github.com/spf13/cobra"
+)
+
+// Config is the content of the webterm config file.
+type Config struct {
+	// Format is the default --format of the tab commands, e.g. json.
+	Format string `toml:"format"`
+	// Browser is the default --browser, WEBTERM_BROWSER takes precedence over it.
+	Browser string `toml:"browser"`
+	// Timeout is the default --timeout of the commands without an entry in Timeouts.
+	Timeout string `toml:"timeout"`
+	// SearchEngine is the default --engine of tab create --search, WEBTERM_SEARCH_ENGINE takes precedence over it.
+	SearchEngine string `toml:"search_engine"`
+	// MaxOpen is the default --max-open of tab create, WEBTERM_MAX_OPEN takes precedence over it.
+	MaxOpen int `toml:"max_open"`
+	// Timeouts maps a command path (e.g. "tab.screenshot", or just "screenshot") to its default timeout.
+	Timeouts map[string]string `toml:"timeouts"`
+}
+
+// config is the config file, loaded by the root command before running any other command.
+var config = &Config{}
+
+func configPath() string {
+	return filepath.Join(xdg.ConfigHome, "webterm", "config.toml")
+}
+
+func loadConfig() (*Config, error) {
+	var config Config
+	if _, err := toml.DecodeFile(configPath(), &config); errors.Is(err, os.ErrNotExist) {
+		return &config, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("invalid config file: %w", err)
+	}
+
+	return &config, nil
+}
+
+// commandTimeout looks up the configured timeout of a command, from the most to the least specific key:
+// the full command path, the command name, then each parent command path.
+func (c *Config) commandTimeout(cmd *cobra.Command) (time.Duration, bool, error) {
+	var parts []string
+	for cur := cmd; cur.HasParent(); cur = cur.Parent() {
+		parts = append([]string{cur.Name()}, parts...)
+	}
+	if len(parts) == 0 {
+		return 0, false, nil
+	}
+
+	keys := []string{strings.Join(parts, "."), cmd.Name()}
+	for i := len(parts) - 1; i > 0; i-- {
+		keys = append(keys, strings.Join(parts[:i], "."))
+	}
+
+	for _, key := range keys {
+		value, ok := c.Timeouts[key]
+		if !ok {
+			continue
+		}
+
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid timeout for %s in config: %w", key, err)
+		}
+
+		return timeout, true, nil
+	}
+
+	return 0, false, nil
+}
+
+// defaultTimeout returns the timeout set for every command in the config.
+func (c *Config) defaultTimeout() (time.Duration, bool, error) {
+	if c.Timeout == "" {
+		return 0, false, nil
+	}
+
+	timeout, err := time.ParseDuration(c.Timeout)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid timeout in config: %w", err)
+	}
+
+	return timeout, true, nil
+}
+
+func NewCmdConfig() *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "config",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:  "path",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Println(configPath())
+			return nil
+		},
+	})
+
+	return cmd
+}
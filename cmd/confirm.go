@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// errNotInteractive is returned by confirm when there is no terminal to ask the user.
+var errNotInteractive = errors.New("stdin is not a terminal")
+
+// confirm asks a yes/no question on stderr, anything but yes is a no.
+func confirm(cmd *cobra.Command, question string) (bool, error) {
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return false, errNotInteractive
+	}
+
+	cmd.PrintErrf("%s [y/N] ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, err
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/spf13/cobra"
+)
+
+type Container struct {
+	Color         string `json:"color"`
+	CookieStoreID string `json:"cookieStoreId"`
+	Icon          string `json:"icon"`
+	Name          string `json:"name"`
+}
+
+func listContainers() ([]Container, error) {
+	res, err := sendMessage(map[string]string{
+		"command": "container.list",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []Container
+	if err := decodeResponse(res, &containers); err != nil {
+		return nil, err
+	}
+
+	return containers, nil
+}
+
+// containerStoreID resolves the cookie store id of the container with the given name.
+func containerStoreID(name string) (string, error) {
+	containers, err := listContainers()
+	if err != nil {
+		return "", err
+	}
+
+	for _, container := range containers {
+		if container.Name == name {
+			return container.CookieStoreID, nil
+		}
+	}
+
+	return "", fmt.Errorf("no container named: %s", name)
+}
+
+func NewCmdContainerList(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "list",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			containers, err := listContainers()
+			if err != nil {
+				return err
+			}
+
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if jsonOutput {
+				if err := writeJSON(cmd, containers); err != nil {
+					return err
+				}
+				return nil
+			}
+
+			for _, container := range containers {
+				printer.AddField(container.Name)
+				printer.AddField(container.Color)
+				printer.AddField(container.CookieStoreID)
+				printer.EndRow()
+			}
+
+			if err := printer.Render(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("json", false, "output as json")
+
+	return cmd
+}
+
+func NewCmdContainer(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "container",
+	}
+
+	cmd.AddCommand(NewCmdContainerList(printer))
+
+	return cmd
+}
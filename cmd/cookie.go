@@ -0,0 +1,399 @@
+package cmd
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/pomdtr/webterm/webterm"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+type Cookie = webterm.Cookie
+
+// encryptedCookies is the on-disk format of an export written with --encrypt.
+type encryptedCookies struct {
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+var domainRegexp = regexp.MustCompile(`^(?i)[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`)
+
+func validateDomain(domain string) error {
+	if !domainRegexp.MatchString(strings.TrimPrefix(domain, ".")) {
+		return fmt.Errorf("invalid domain: %s", domain)
+	}
+
+	return nil
+}
+
+func readPassphrase() ([]byte, error) {
+	if passphrase, ok := os.LookupEnv("WEBTERM_PASSPHRASE"); ok {
+		return []byte(passphrase), nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil, fmt.Errorf("set WEBTERM_PASSPHRASE or run from a terminal to enter a passphrase")
+	}
+
+	fmt.Fprint(os.Stderr, "Passphrase: ")
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read passphrase: %w", err)
+	}
+
+	return passphrase, nil
+}
+
+func cookieCipher(passphrase []byte, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func encryptCookies(plaintext []byte) ([]byte, error) {
+	passphrase, err := readPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := cookieCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(encryptedCookies{
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, nil),
+	}, "", "  ")
+}
+
+func decryptCookies(envelope encryptedCookies) ([]byte, error) {
+	passphrase, err := readPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cookieCipher(passphrase, envelope.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := aead.Open(nil, envelope.Nonce, envelope.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt cookies, wrong passphrase?")
+	}
+
+	return plaintext, nil
+}
+
+func NewCmdCookieExport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "export",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domain, _ := cmd.Flags().GetString("domain")
+			if err := validateDomain(domain); err != nil {
+				return err
+			}
+
+			cookies, err := client.GetCookies(cmd.Context(), webterm.CookieQuery{Domain: domain})
+			if err != nil {
+				return err
+			}
+
+			output, err := json.MarshalIndent(cookies, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			encrypt, _ := cmd.Flags().GetBool("encrypt")
+			if encrypt {
+				output, err = encryptCookies(output)
+				if err != nil {
+					return err
+				}
+			}
+			output = append(output, '\n')
+
+			outputPath, _ := cmd.Flags().GetString("output")
+			if outputPath == "" {
+				if _, err := os.Stdout.Write(output); err != nil {
+					return err
+				}
+				return nil
+			}
+
+			if err := os.WriteFile(outputPath, output, 0600); err != nil {
+				return fmt.Errorf("unable to write cookies file: %w", err)
+			}
+
+			cmd.Printf("Exported %d cookies to %s\n", len(cookies), outputPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("domain", "", "domain to export cookies for")
+	cmd.Flags().StringP("output", "o", "", "file to write the cookies to")
+	cmd.Flags().Bool("encrypt", false, "encrypt the cookies with a passphrase")
+	cmd.MarkFlagRequired("domain")
+
+	return cmd
+}
+
+func NewCmdCookieImport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "import <file>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			content, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("unable to read cookies file: %w", err)
+			}
+
+			var envelope encryptedCookies
+			if err := json.Unmarshal(content, &envelope); err == nil && len(envelope.Ciphertext) > 0 {
+				content, err = decryptCookies(envelope)
+				if err != nil {
+					return err
+				}
+			}
+
+			var cookies []Cookie
+			if err := json.Unmarshal(content, &cookies); err != nil {
+				return fmt.Errorf("invalid cookies file: %w", err)
+			}
+
+			for _, cookie := range cookies {
+				if err := validateDomain(cookie.Domain); err != nil {
+					return err
+				}
+
+				if err := client.SetCookie(cmd.Context(), cookie); err != nil {
+					return fmt.Errorf("unable to set cookie %s: %w", cookie.Name, err)
+				}
+			}
+
+			cmd.Printf("Imported %d cookies\n", len(cookies))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// getCookies returns the cookies sent to the given url, or to the url of the active tab when it is empty.
+func getCookies(cmd *cobra.Command, url string) ([]Cookie, error) {
+	if url == "" {
+		tab, err := client.GetActiveTab(cmd.Context())
+		if err != nil {
+			return nil, err
+		}
+		url = tab.URL
+	}
+
+	return client.GetCookies(cmd.Context(), webterm.CookieQuery{URL: url})
+}
+
+// matchCookieDomain reports whether a cookie is set for the given domain or one of its subdomains.
+func matchCookieDomain(cookie Cookie, domain string) bool {
+	cookieDomain := strings.TrimPrefix(cookie.Domain, ".")
+	domain = strings.TrimPrefix(domain, ".")
+	return cookieDomain == domain || strings.HasSuffix(cookieDomain, "."+domain)
+}
+
+func NewCmdCookieList(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "list [url]",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var url string
+			if len(args) > 0 {
+				url = args[0]
+			}
+
+			// without a url, the domain is enough to look the cookies up
+			domain, _ := cmd.Flags().GetString("domain")
+			var cookies []Cookie
+			if url == "" && domain != "" {
+				if err := validateDomain(domain); err != nil {
+					return err
+				}
+
+				res, err := client.GetCookies(cmd.Context(), webterm.CookieQuery{Domain: domain})
+				if err != nil {
+					return err
+				}
+				cookies = res
+			} else {
+				res, err := getCookies(cmd, url)
+				if err != nil {
+					return err
+				}
+				cookies = res
+			}
+
+			if domain != "" {
+				var kept []Cookie
+				for _, cookie := range cookies {
+					if matchCookieDomain(cookie, domain) {
+						kept = append(kept, cookie)
+					}
+				}
+				cookies = kept
+			}
+
+			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+				if cookies == nil {
+					cookies = []Cookie{}
+				}
+				return writeJSON(cmd, cookies)
+			}
+
+			for _, cookie := range cookies {
+				printer.AddField(cookie.Name)
+				printer.AddField(cookie.Value)
+				printer.AddField(cookie.Domain)
+				printer.AddField(cookie.Path)
+				printer.EndRow()
+			}
+
+			return printer.Render()
+		},
+	}
+
+	cmd.Flags().String("domain", "", "only list the cookies of the given domain and its subdomains")
+	cmd.Flags().Bool("json", false, "output as json")
+
+	return cmd
+}
+
+func NewCmdCookieGet() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "get <name>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			url, _ := cmd.Flags().GetString("url")
+			cookies, err := getCookies(cmd, url)
+			if err != nil {
+				return err
+			}
+
+			for _, cookie := range cookies {
+				if cookie.Name != args[0] {
+					continue
+				}
+
+				if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+					return writeJSON(cmd, cookie)
+				}
+
+				fmt.Println(cookie.Value)
+				return nil
+			}
+
+			return webterm.NotFoundf("no cookie named %s", args[0])
+		},
+	}
+
+	cmd.Flags().String("url", "", "url the cookie is sent to, defaults to the url of the active tab")
+	cmd.Flags().Bool("json", false, "output as json")
+
+	return cmd
+}
+
+func NewCmdCookieClear() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "clear",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domain, _ := cmd.Flags().GetString("domain")
+			all, _ := cmd.Flags().GetBool("all")
+			if domain == "" && !all {
+				return fmt.Errorf("either --domain or --all is required")
+			}
+
+			if domain != "" {
+				if err := validateDomain(domain); err != nil {
+					return err
+				}
+			}
+
+			cookies, err := client.GetCookies(cmd.Context(), webterm.CookieQuery{Domain: domain})
+			if err != nil {
+				return err
+			}
+
+			if force, _ := cmd.Flags().GetBool("force"); all && !force && len(cookies) > 0 {
+				ok, err := confirm(cmd, fmt.Sprintf("Clear %s from every site?", pluralize(len(cookies), "cookie")))
+				if err != nil {
+					return fmt.Errorf("%d cookies would be cleared, use --force to clear them all", len(cookies))
+				}
+				if !ok {
+					return fmt.Errorf("aborted")
+				}
+			}
+
+			for _, cookie := range cookies {
+				if err := client.RemoveCookie(cmd.Context(), cookie); err != nil {
+					return fmt.Errorf("unable to clear cookie %s: %w", cookie.Name, err)
+				}
+			}
+
+			cmd.Printf("Cleared %s\n", pluralize(len(cookies), "cookie"))
+			return nil
+		},
+	}
+
+	cmd.Flags().String("domain", "", "clear the cookies of the given domain and its subdomains")
+	cmd.Flags().Bool("all", false, "clear the cookies of every site")
+	cmd.Flags().Bool("force", false, "do not ask for confirmation with --all")
+	cmd.MarkFlagsMutuallyExclusive("domain", "all")
+
+	return cmd
+}
+
+func NewCmdCookie(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "cookie",
+		Aliases: []string{"cookies"},
+	}
+
+	cmd.AddCommand(NewCmdCookieList(printer))
+	cmd.AddCommand(NewCmdCookieGet())
+	cmd.AddCommand(NewCmdCookieClear())
+	cmd.AddCommand(NewCmdCookieExport())
+	cmd.AddCommand(NewCmdCookieImport())
+
+	return cmd
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import "testing"
+
+func TestCookieClear(t *testing.T) {
+	isolateConfig(t)
+
+	transport := &fakeTransport{responses: map[string]string{
+		"cookie.getAll": `[{"name": "sid", "domain": ".github.com", "path": "/", "secure": true, "storeId": "0"}]`,
+	}}
+	stdout, stderr, err := runCommand(t, transport, "cookie", "clear", "--domain", "github.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+	}
+
+	transport.assertSent(t,
+		`{"command": "cookie.getAll", "domain": "github.com"}`,
+		`{"command": "cookie.remove", "details": {"url": "https://github.com/", "name": "sid", "storeId": "0"}}`,
+	)
+	if want := "Cleared 1 cookie\n"; stdout+stderr != want {
+		t.Errorf("output = %q, want %q", stdout+stderr, want)
+	}
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdTabCount() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "count",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tabs, err := listWindowTabs(cmd)
+			if err != nil {
+				return err
+			}
+
+			tabs, err = selectTabs(cmd, tabs)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(len(tabs))
+			return nil
+		},
+	}
+
+	addWindowFlags(cmd)
+	addFilterFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,507 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pomdtr/webterm/webterm"
+	"github.com/spf13/cobra"
+)
+
+// maxOpenDefault returns the tab limit used when --max-open is not given, 0 when there is none.
+func maxOpenDefault() int {
+	if maxOpen, err := strconv.Atoi(os.Getenv("WEBTERM_MAX_OPEN")); err == nil {
+		return maxOpen
+	}
+
+	return config.MaxOpen
+}
+
+// searchEngines maps the --engine presets to the url prefix the query is appended to.
+var searchEngines = map[string]string{
+	"duckduckgo": "https://duckduckgo.com/?q=",
+	"google":     "https://www.google.com/search?q=",
+	"bing":       "https://www.bing.com/search?q=",
+	"github":     "https://github.com/search?q=",
+	"wikipedia":  "https://en.wikipedia.org/w/index.php?search=",
+}
+
+// defaultSearchEngine returns the search engine used when --engine is not given.
+func defaultSearchEngine() string {
+	if engine := os.Getenv("WEBTERM_SEARCH_ENGINE"); engine != "" {
+		return engine
+	}
+
+	if config.SearchEngine != "" {
+		return config.SearchEngine
+	}
+
+	return "duckduckgo"
+}
+
+// searchURL builds the url searching query with engine, either the name of a preset or a url prefix such as https://example.com/?q=.
+func searchURL(engine string, query string) (string, error) {
+	prefix, ok := searchEngines[engine]
+	if !ok {
+		if u, err := url.Parse(engine); err != nil || u.Scheme == "" {
+			names := make([]string, 0, len(searchEngines))
+			for name := range searchEngines {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return "", fmt.Errorf("invalid search engine: %s, expected a url or one of %s", engine, strings.Join(names, ", "))
+		}
+		prefix = engine
+	}
+
+	return prefix + url.QueryEscape(query), nil
+}
+
+// hostPortRegexp matches what url.Parse takes for the opaque part of a url when it is given host:port, e.g. localhost:3000.
+var hostPortRegexp = regexp.MustCompile(`^\d+(/|$)`)
+
+// normalizeURL turns the path of an existing local file into a file:// url and prefixes
+// the arguments without a scheme with https://, other urls are left untouched.
+func normalizeURL(arg string) (string, error) {
+	return normalizeURLScheme(arg, "https")
+}
+
+// normalizeURLScheme is normalizeURL, prefixing the urls without a scheme with the given one.
+func normalizeURLScheme(arg string, scheme string) (string, error) {
+	u, err := url.Parse(arg)
+	if err == nil && u.Scheme != "" && !filepath.IsAbs(arg) && !hostPortRegexp.MatchString(u.Opaque) {
+		return arg, nil
+	}
+
+	if _, err := os.Stat(arg); err == nil {
+		path, err := filepath.Abs(arg)
+		if err != nil {
+			return "", fmt.Errorf("unable to resolve path %s: %w", arg, err)
+		}
+
+		u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+		if !strings.HasPrefix(u.Path, "/") {
+			// windows paths start with a drive letter
+			u.Path = "/" + u.Path
+		}
+
+		return u.String(), nil
+	}
+
+	prefixed := scheme + "://" + arg
+	u, err = url.Parse(prefixed)
+	if err != nil {
+		return "", fmt.Errorf("invalid url %q: %w", arg, err)
+	}
+
+	if u.Hostname() == "" || strings.ContainsAny(u.Host, " \t") {
+		return "", fmt.Errorf("invalid url %q: it has no scheme and does not start with a host name", arg)
+	}
+
+	return prefixed, nil
+}
+
+// readURLFile reads a url per line of a file, - for stdin, skipping blank lines and # comments.
+func readURLFile(path string) ([]string, error) {
+	var content []byte
+	var err error
+	if path == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read urls: %w", err)
+	}
+
+	var urls []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		urls = append(urls, line)
+	}
+
+	return urls, nil
+}
+
+// checkIncognitoWindow returns an error unless the window exists and is incognito.
+func checkIncognitoWindow(ctx context.Context, windowId int) error {
+	windows, err := client.ListWindows(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, window := range windows {
+		if window.ID != windowId {
+			continue
+		}
+
+		if !window.Incognito {
+			return fmt.Errorf("window %d is not an incognito window, drop --window to open the tabs in an incognito window", windowId)
+		}
+		return nil
+	}
+
+	return webterm.NotFoundf("no window with id %d", windowId)
+}
+
+// waitForTabs waits for the tabs to finish loading within the --timeout of the command, and returns their loaded state.
+// The tabs which did not load are reported on stderr and kept as they were.
+func waitForTabs(cmd *cobra.Command, tabs []Tab) ([]Tab, error) {
+	timeout, err := resolveTimeout(cmd)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(timeout)
+
+	loaded := make([]Tab, len(tabs))
+	var failed int
+	for i, tab := range tabs {
+		loaded[i] = tab
+
+		// the tabs load in parallel, so they all share the same deadline
+		remaining := time.Duration(0)
+		if timeout > 0 {
+			if remaining = time.Until(deadline); remaining <= 0 {
+				remaining = time.Nanosecond
+			}
+		}
+
+		updated, err := waitForTab(cmd.Context(), tab.ID, 200*time.Millisecond, remaining)
+		if err != nil {
+			if timeout > 0 && !time.Now().Before(deadline) {
+				cmd.PrintErrf("Tab %d did not finish loading: %s\n", tab.ID, tab.URL)
+			} else {
+				cmd.PrintErrf("Tab %d: %s\n", tab.ID, err)
+			}
+			failed++
+			continue
+		}
+		loaded[i] = *updated
+	}
+
+	if failed > 0 {
+		return loaded, fmt.Errorf("%s did not finish loading within %s", pluralize(failed, "tab"), timeout)
+	}
+
+	return loaded, nil
+}
+
+func NewCmdTabCreate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "create",
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completeHistoryURLs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := &webterm.CreateTabsOptions{}
+
+			var urls []string
+			if search, _ := cmd.Flags().GetBool("search"); search {
+				if len(args) == 0 {
+					return fmt.Errorf("--search requires a query")
+				}
+				if cmd.Flags().Changed("file") {
+					return fmt.Errorf("--search and --file are mutually exclusive")
+				}
+
+				engine, _ := cmd.Flags().GetString("engine")
+				if engine == "" {
+					engine = defaultSearchEngine()
+				}
+				u, err := searchURL(engine, strings.Join(args, " "))
+				if err != nil {
+					return err
+				}
+				urls = []string{u}
+			} else {
+				if cmd.Flags().Changed("engine") {
+					return fmt.Errorf("--engine requires --search")
+				}
+
+				targets := args
+				if file, _ := cmd.Flags().GetString("file"); file != "" {
+					lines, err := readURLFile(file)
+					if err != nil {
+						return err
+					}
+					targets = append(targets, lines...)
+				}
+
+				scheme, _ := cmd.Flags().GetString("default-scheme")
+				for _, target := range targets {
+					u, err := normalizeURLScheme(target, scheme)
+					if err != nil {
+						return err
+					}
+					urls = append(urls, u)
+				}
+			}
+
+			focus, _ := cmd.Flags().GetBool("focus")
+			focusLast, _ := cmd.Flags().GetBool("focus-last")
+			if focus && focusLast {
+				return fmt.Errorf("--focus and --focus-last are mutually exclusive")
+			}
+
+			focusExisting, _ := cmd.Flags().GetBool("focus-existing")
+			skipOpen, _ := cmd.Flags().GetBool("skip-open")
+			skipOpen = skipOpen || focusExisting
+			skipVisited, _ := cmd.Flags().GetBool("skip-visited")
+			dedupe := len(urls) > 0 && (skipOpen || skipVisited)
+
+			var existing *Tab
+			skipped := 0
+			if dedupe {
+				openTabs := make(map[string]Tab)
+				if skipOpen {
+					tabs, err := client.ListAllTabs(cmd.Context())
+					if err != nil {
+						return err
+					}
+
+					for _, tab := range tabs {
+						key := dedupeKey(tab.URL, false, false)
+						if _, ok := openTabs[key]; !ok {
+							openTabs[key] = tab
+						}
+					}
+				}
+
+				var kept []string
+				for _, u := range urls {
+					if tab, ok := openTabs[dedupeKey(u, false, false)]; ok {
+						cmd.PrintErrf("Skipped %s: already open in tab %d\n", u, tab.ID)
+						if existing == nil {
+							existing = &tab
+						}
+						skipped++
+						continue
+					}
+
+					if skipVisited {
+						visited, err := visitedRecently(u)
+						if err != nil {
+							return err
+						}
+
+						if visited {
+							cmd.PrintErrf("Skipped %s: visited recently\n", u)
+							skipped++
+							continue
+						}
+					}
+
+					kept = append(kept, u)
+				}
+				urls = kept
+			}
+
+			maxOpen := maxOpenDefault()
+			if cmd.Flags().Changed("max-open") {
+				maxOpen, _ = cmd.Flags().GetInt("max-open")
+			}
+			force, _ := cmd.Flags().GetBool("force")
+			if maxOpen > 0 && !force {
+				tabs, err := client.ListAllTabs(cmd.Context())
+				if err != nil {
+					return err
+				}
+
+				if len(tabs)+len(urls) > maxOpen {
+					return fmt.Errorf("refusing to open %d tabs: %d tabs already open, limit is %d (use --force to override)", len(urls), len(tabs), maxOpen)
+				}
+			}
+
+			if container, _ := cmd.Flags().GetString("container"); container != "" {
+				cookieStoreId, err := containerStoreID(container)
+				if err != nil {
+					return err
+				}
+				opts.CookieStoreID = cookieStoreId
+			}
+
+			onLoad, _ := cmd.Flags().GetString("on-load")
+			onLoadFile, _ := cmd.Flags().GetString("on-load-file")
+			script, err := readScript(onLoad, onLoadFile)
+			if err != nil {
+				return err
+			}
+
+			active, _ := cmd.Flags().GetBool("active")
+			noActive, _ := cmd.Flags().GetBool("no-active")
+			opts.Inactive = noActive || !active
+			opts.Pinned, _ = cmd.Flags().GetBool("pinned")
+
+			incognito, _ := cmd.Flags().GetBool("incognito")
+			if cmd.Flags().Changed("window") {
+				windowId, _ := cmd.Flags().GetInt("window")
+				opts.WindowID = windowId
+
+				if incognito {
+					// tabs can not be opened in incognito from a normal window
+					if err := checkIncognitoWindow(cmd.Context(), windowId); err != nil {
+						return err
+					}
+				}
+			}
+
+			opts.Incognito = incognito
+
+			lazy, _ := cmd.Flags().GetBool("lazy")
+			waitComplete, _ := cmd.Flags().GetBool("wait-complete")
+			if lazy {
+				if script != "" {
+					return fmt.Errorf("--on-load can not be used with --lazy, lazy tabs do not load")
+				}
+				if waitComplete {
+					return fmt.Errorf("--wait-complete can not be used with --lazy, lazy tabs do not load")
+				}
+				opts.Inactive = true
+			}
+
+			var tabs []Tab
+			if !dedupe || len(urls) > 0 {
+				created, err := client.CreateTabs(cmd.Context(), urls, opts)
+				if err != nil {
+					return err
+				}
+				tabs = created
+			}
+
+			if dedupe && !lazy {
+				for i, tab := range tabs {
+					cmd.PrintErrf("Opened %s in tab %d\n", urls[i], tab.ID)
+				}
+			}
+
+			if lazy {
+				tabIds := make([]int, len(tabs))
+				for i, tab := range tabs {
+					tabIds[i] = tab.ID
+				}
+
+				// discarding a tab may replace its id, so report the discarded ones
+				discarded, err := client.DiscardTabs(cmd.Context(), tabIds...)
+				if err != nil {
+					return err
+				}
+				if discarded != nil {
+					tabs = discarded
+				}
+
+				for i, tab := range tabs {
+					cmd.Printf("Created tab %d lazily: %s\n", tab.ID, urls[i])
+				}
+			}
+
+			if (focus || focusLast) && len(tabs) > 0 {
+				i := 0
+				if focusLast {
+					i = len(tabs) - 1
+				}
+
+				if err := client.FocusTab(cmd.Context(), tabs[i].ID); err != nil {
+					return err
+				}
+
+				cmd.Printf("Focused tab %d: %s\n", tabs[i].ID, urls[i])
+			} else if focusExisting && existing != nil {
+				if err := client.FocusTab(cmd.Context(), existing.ID); err != nil {
+					return err
+				}
+
+				cmd.Printf("Focused tab %d: %s\n", existing.ID, existing.URL)
+			}
+
+			var loadErr error
+			if waitComplete {
+				tabs, loadErr = waitForTabs(cmd, tabs)
+			}
+
+			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+				if err := writeJSON(cmd, newTabOutputs(tabs)); err != nil {
+					return err
+				}
+			} else if !lazy {
+				for _, tab := range tabs {
+					fmt.Println(tab.ID)
+				}
+			}
+
+			printSummary(cmd, "created", len(tabs), skipped)
+
+			if loadErr != nil {
+				return loadErr
+			}
+
+			if script == "" {
+				return nil
+			}
+
+			timeout, err := resolveTimeout(cmd)
+			if err != nil {
+				return err
+			}
+
+			var failed int
+			for _, tab := range tabs {
+				if _, err := waitForTab(cmd.Context(), tab.ID, 500*time.Millisecond, timeout); err != nil {
+					cmd.PrintErrf("Tab %d: %s\n", tab.ID, err)
+					failed++
+					continue
+				}
+
+				result, err := execScript(tab.ID, script)
+				if err != nil {
+					cmd.PrintErrf("Tab %d: %s\n", tab.ID, err)
+					failed++
+					continue
+				}
+
+				fmt.Printf("Tab %d: %s\n", tab.ID, result)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("on-load script failed in %s", pluralize(failed, "tab"))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("active", true, "focus the created tabs in their window")
+	cmd.Flags().Bool("no-active", false, "open the tabs in the background, same as --active=false")
+	cmd.Flags().Bool("pinned", false, "pin the created tabs")
+	cmd.Flags().Int("window", 0, "id of the window to open the tabs in, defaults to the current one")
+	cmd.Flags().Bool("lazy", false, "open tabs unloaded, they load when focused")
+	cmd.Flags().Int("max-open", 0, "refuse to open tabs past this many open tabs, 0 to disable (default from WEBTERM_MAX_OPEN, then the config)")
+	cmd.Flags().Bool("force", false, "ignore the --max-open limit")
+	cmd.Flags().Bool("focus", false, "focus the first created tab")
+	cmd.Flags().Bool("focus-last", false, "focus the last created tab")
+	cmd.Flags().Bool("skip-open", false, "do not open urls that are already open")
+	cmd.Flags().Bool("focus-existing", false, "focus the first already open url instead of opening it again, implies --skip-open")
+	cmd.Flags().Bool("skip-visited", false, "do not open urls visited in the last 24 hours")
+	cmd.Flags().Bool("wait-complete", false, "wait for the created tabs to finish loading, up to --timeout")
+	cmd.Flags().String("on-load", "", "javascript to run in each tab once loaded, waiting up to --timeout for it")
+	cmd.Flags().String("on-load-file", "", "file containing the javascript to run in each tab once loaded")
+	cmd.MarkFlagsMutuallyExclusive("on-load", "on-load-file")
+	cmd.Flags().String("container", "", "name of the container to open the tabs in (firefox only)")
+	cmd.Flags().String("file", "", "read urls to open from a file, one per line, - for stdin")
+	cmd.Flags().String("default-scheme", "https", "scheme of the urls given without one")
+	cmd.Flags().Bool("incognito", false, "open the tabs in an incognito window, an existing one or a new one")
+	cmd.Flags().Bool("search", false, "open a search for the arguments instead of urls")
+	cmd.Flags().String("engine", "", "search engine used by --search: duckduckgo, google, bing, github, wikipedia or a url prefix (default from WEBTERM_SEARCH_ENGINE, then the config, then duckduckgo)")
+
+	return cmd
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTabCreateSearchEngine(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		env     string
+		args    []string
+		wantURL string
+	}{
+		{
+			name:    "built-in default",
+			wantURL: "https://duckduckgo.com/?q=go+generics",
+		},
+		{
+			name:    "config",
+			config:  `search_engine = "google"`,
+			wantURL: "https://www.google.com/search?q=go+generics",
+		},
+		{
+			name:    "environment over config",
+			config:  `search_engine = "google"`,
+			env:     "bing",
+			wantURL: "https://www.bing.com/search?q=go+generics",
+		},
+		{
+			name:    "flag over environment",
+			env:     "bing",
+			args:    []string{"--engine", "github"},
+			wantURL: "https://github.com/search?q=go+generics",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := isolateConfig(t)
+			if tt.config != "" {
+				if err := os.MkdirAll(filepath.Join(dir, "webterm"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, "webterm", "config.toml"), []byte(tt.config), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			t.Setenv("WEBTERM_SEARCH_ENGINE", tt.env)
+
+			transport := &fakeTransport{responses: map[string]string{"tab.create": `[{"id": 5}]`}}
+			args := append([]string{"tab", "create", "--search", "go", "generics", "--quiet"}, tt.args...)
+			if _, stderr, err := runCommand(t, transport, args...); err != nil {
+				t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+			}
+
+			transport.assertSent(t, `{"command": "tab.create", "urls": ["`+tt.wantURL+`"]}`)
+		})
+	}
+}
+
+func TestTabCreateSkipOpen(t *testing.T) {
+	isolateConfig(t)
+
+	transport := &fakeTransport{responses: map[string]string{
+		"tab.list":   `[{"id": 1, "url": "https://example.com/"}]`,
+		"tab.create": `[{"id": 5}]`,
+	}}
+	_, stderr, err := runCommand(t, transport, "tab", "create", "--skip-open", "example.com", "go.dev")
+	if err != nil {
+		t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+	}
+
+	transport.assertSent(t,
+		`{"command": "tab.list", "allWindows": true}`,
+		`{"command": "tab.create", "urls": ["https://go.dev"]}`,
+	)
+	if !strings.Contains(stderr, "Skipped https://example.com: already open in tab 1") {
+		t.Errorf("stderr = %q, want the skipped url", stderr)
+	}
+}
+
+func TestTabCreateMaxOpen(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		env     string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name: "no limit",
+		},
+		{
+			name:    "config",
+			config:  `max_open = 2`,
+			wantErr: true,
+		},
+		{
+			name:   "environment over config",
+			config: `max_open = 2`,
+			env:    "3",
+		},
+		{
+			name:    "flag over environment",
+			env:     "3",
+			args:    []string{"--max-open", "2"},
+			wantErr: true,
+		},
+		{
+			name:   "flag disables the config limit",
+			config: `max_open = 2`,
+			args:   []string{"--max-open", "0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := isolateConfig(t)
+			if tt.config != "" {
+				if err := os.MkdirAll(filepath.Join(dir, "webterm"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, "webterm", "config.toml"), []byte(tt.config), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			t.Setenv("WEBTERM_MAX_OPEN", tt.env)
+
+			transport := &fakeTransport{responses: map[string]string{
+				"tab.list":   `[{"id": 1}, {"id": 2}]`,
+				"tab.create": `[{"id": 5}]`,
+			}}
+			args := append([]string{"tab", "create", "https://go.dev", "--quiet"}, tt.args...)
+			_, stderr, err := runCommand(t, transport, args...)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "refusing to open 1 tabs") {
+					t.Fatalf("got error %v, want the limit to be hit", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+			}
+
+			commands := transport.commands()
+			if commands[len(commands)-1] != "tab.create" {
+				t.Errorf("sent %v, want the tab to be created", commands)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// cycleTab focuses the tab step positions away from the active tab of the current window.
+// Without wrap, the focus stays on the first or last tab instead of going around.
+func cycleTab(cmd *cobra.Command, step int, wrap bool) error {
+	active, err := client.GetActiveTab(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	tabs, err := client.ListWindowTabs(cmd.Context(), active.WindowID)
+	if err != nil {
+		return err
+	}
+
+	index := active.Index + step
+	if wrap {
+		index = (index%len(tabs) + len(tabs)) % len(tabs)
+	} else if index < 0 || index >= len(tabs) {
+		return nil
+	}
+
+	for _, tab := range tabs {
+		if tab.Index == index {
+			return client.FocusTab(cmd.Context(), tab.ID)
+		}
+	}
+
+	return nil
+}
+
+func NewCmdTabActivateNext() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "activate-next",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			noWrap, _ := cmd.Flags().GetBool("no-wrap")
+			return cycleTab(cmd, 1, !noWrap)
+		},
+	}
+
+	cmd.Flags().Bool("no-wrap", false, "stay on the last tab instead of going back to the first one")
+
+	return cmd
+}
+
+func NewCmdTabActivatePrev() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "activate-prev",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			noWrap, _ := cmd.Flags().GetBool("no-wrap")
+			return cycleTab(cmd, -1, !noWrap)
+		},
+	}
+
+	cmd.Flags().Bool("no-wrap", false, "stay on the first tab instead of going to the last one")
+
+	return cmd
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/pomdtr/webterm/webterm"
+)
+
+// decodeResponse unmarshals a response of the extension, the error quoting the start of the response,
+// or all of it with --verbose, when it does not have the expected shape.
+func decodeResponse[T any](raw []byte, out *T) error {
+	if err := json.Unmarshal(raw, out); err != nil {
+		return &webterm.DecodeError{Err: err, Body: raw}
+	}
+
+	return nil
+}
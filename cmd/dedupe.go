@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// dedupeKey normalizes a tab url so that equivalent urls compare equal.
+func dedupeKey(rawURL string, ignoreQuery bool, ignoreFragment bool) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	// the browser reports the root of a site with a trailing slash
+	if u.Path == "" && u.Opaque == "" && u.Host != "" {
+		u.Path = "/"
+	}
+	if ignoreQuery {
+		u.RawQuery = ""
+		u.ForceQuery = false
+	}
+	if ignoreFragment {
+		u.Fragment = ""
+		u.RawFragment = ""
+	}
+
+	return u.String()
+}
+
+func NewCmdTabDedupe() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "dedupe",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ignoreQuery, _ := cmd.Flags().GetBool("ignore-query")
+			ignoreFragment, _ := cmd.Flags().GetBool("ignore-fragment")
+			includePinned, _ := cmd.Flags().GetBool("include-pinned")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+			tabs, err := listWindowTabs(cmd)
+			if err != nil {
+				return err
+			}
+
+			sort.SliceStable(tabs, func(i, j int) bool {
+				if tabs[i].WindowID != tabs[j].WindowID {
+					return tabs[i].WindowID < tabs[j].WindowID
+				}
+				return tabs[i].Index < tabs[j].Index
+			})
+
+			var keys []string
+			sets := make(map[string][]Tab)
+			for _, tab := range tabs {
+				key := dedupeKey(tab.URL, ignoreQuery, ignoreFragment)
+				if _, ok := sets[key]; !ok {
+					keys = append(keys, key)
+				}
+				sets[key] = append(sets[key], tab)
+			}
+
+			var tabIds []int
+			for _, key := range keys {
+				set := sets[key]
+				if len(set) < 2 {
+					continue
+				}
+
+				// a pinned tab is kept over the first one, since it would not be closed anyway
+				keep := set[0]
+				if !includePinned {
+					for _, tab := range set {
+						if tab.Pinned {
+							keep = tab
+							break
+						}
+					}
+				}
+
+				for _, tab := range set {
+					if tab.ID == keep.ID || (tab.Pinned && !includePinned) {
+						continue
+					}
+
+					if dryRun {
+						cmd.PrintErrf("Would close tab %d: %s (duplicate of tab %d)\n", tab.ID, tab.URL, keep.ID)
+					}
+					tabIds = append(tabIds, tab.ID)
+				}
+			}
+
+			if dryRun {
+				printSummary(cmd, "would close", len(tabIds), 0)
+				return nil
+			}
+
+			count := 0
+			if len(tabIds) > 0 {
+				closed, err := client.CloseTabs(cmd.Context(), tabIds...)
+				if err != nil {
+					return err
+				}
+				count = affectedCount(closed, tabIds)
+			}
+
+			printSummary(cmd, "closed", count, 0)
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("ignore-query", false, "consider urls differing only by their query as duplicates")
+	cmd.Flags().Bool("ignore-fragment", false, "consider urls differing only by their fragment as duplicates")
+	cmd.Flags().Bool("include-pinned", false, "also close pinned duplicates")
+	cmd.Flags().Bool("dry-run", false, "print the duplicate tabs without closing them")
+	addWindowFlags(cmd)
+
+	return cmd
+}
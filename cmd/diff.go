@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+// fetchTabContent returns the html source of a tab, or its rendered text when text is set.
+func fetchTabContent(tabId int, text bool) (string, error) {
+	command := "tab.source"
+	if text {
+		command = "tab.text"
+	}
+
+	res, err := sendMessage(map[string]any{
+		"command": command,
+		"tabId":   tabId,
+	})
+	if err != nil {
+		return "", explainScriptingError(err, "")
+	}
+
+	var content string
+	if err := decodeResponse(res, &content); err != nil {
+		return "", err
+	}
+
+	return content, nil
+}
+
+func NewCmdTabDiff() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "diff <id1> <id2> | diff <snapshot>",
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// a single argument is a file written by tab snapshot
+			if len(args) == 1 {
+				if cmd.Flags().Changed("text") || cmd.Flags().Changed("output") {
+					return fmt.Errorf("--text and --output can only be used when comparing two tabs")
+				}
+				return runSnapshotDiff(cmd, args[0])
+			}
+			if cmd.Flags().Changed("by") {
+				return fmt.Errorf("--by can only be used when comparing with a snapshot")
+			}
+
+			tabIds := make([]int, len(args))
+			for i, arg := range args {
+				id, err := resolveSingleTabID(arg)
+				if err != nil {
+					return err
+				}
+				tabIds[i] = id
+			}
+
+			text, _ := cmd.Flags().GetBool("text")
+			contents := make([]string, len(tabIds))
+			for i, tabId := range tabIds {
+				content, err := fetchTabContent(tabId, text)
+				if err != nil {
+					return err
+				}
+				contents[i] = content
+			}
+
+			diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(contents[0]),
+				B:        difflib.SplitLines(contents[1]),
+				FromFile: fmt.Sprintf("tab %d", tabIds[0]),
+				ToFile:   fmt.Sprintf("tab %d", tabIds[1]),
+				Context:  3,
+			})
+			if err != nil {
+				return err
+			}
+
+			output, _ := cmd.Flags().GetString("output")
+			if output != "" {
+				if err := os.WriteFile(output, []byte(diff), 0644); err != nil {
+					return fmt.Errorf("unable to write diff: %w", err)
+				}
+				return nil
+			}
+
+			if _, err := os.Stdout.WriteString(diff); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("text", false, "compare the rendered text instead of the html source")
+	cmd.Flags().StringP("output", "o", "", "file to write the diff to")
+	cmd.Flags().String("by", "url", "when comparing with a snapshot, match the tabs by url or id")
+
+	return cmd
+}
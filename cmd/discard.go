@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// lastAccessedTime converts the lastAccessed timestamp of a tab, in milliseconds since the epoch.
+func lastAccessedTime(tab Tab) time.Time {
+	return time.UnixMilli(int64(tab.LastAccessed))
+}
+
+func NewCmdTabDiscard() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "discard [tabId]...",
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completeTabIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			olderThan, _ := cmd.Flags().GetDuration("older-than")
+			if len(args) > 0 {
+				if cmd.Flags().Changed("older-than") || cmd.Flags().Changed("status") {
+					return fmt.Errorf("--older-than and --status can not be used with tab ids")
+				}
+				if cmd.Flags().Changed("window") || cmd.Flags().Changed("current") || cmd.Flags().Changed("all-windows") {
+					return fmt.Errorf("--window, --current and --all-windows can not be used with tab ids")
+				}
+
+				tabIds, err := resolveTabIDs(args)
+				if err != nil {
+					return err
+				}
+
+				if _, err := client.DiscardTabs(cmd.Context(), tabIds...); err != nil {
+					return err
+				}
+
+				printSummary(cmd, "discarded", len(tabIds), 0)
+				return nil
+			}
+
+			tabs, err := listWindowTabs(cmd)
+			if err != nil {
+				return err
+			}
+
+			tabs, err = filterTabsByStatus(cmd, tabs)
+			if err != nil {
+				return err
+			}
+
+			cutoff := time.Now().Add(-olderThan)
+			var tabIds []int
+			for _, tab := range tabs {
+				// the active tab can not be discarded, pinned tabs are expected to be kept at hand
+				if tab.Active || tab.Pinned || tab.Discarded {
+					continue
+				}
+
+				if olderThan > 0 && (tab.LastAccessed == 0 || lastAccessedTime(tab).After(cutoff)) {
+					continue
+				}
+
+				tabIds = append(tabIds, tab.ID)
+			}
+
+			if len(tabIds) > 0 {
+				if _, err := client.DiscardTabs(cmd.Context(), tabIds...); err != nil {
+					return err
+				}
+			}
+
+			printSummary(cmd, "discarded", len(tabIds), 0)
+			return nil
+		},
+	}
+
+	cmd.Flags().Duration("older-than", 0, "only discard tabs that were not accessed for this long, e.g. 2h")
+	addStatusFlag(cmd)
+	addWindowFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCmdDoctor() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "doctor",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info, err := client.Ping(cmd.Context())
+			if info == nil {
+				cmd.Printf("Native host: not running\n")
+				return err
+			}
+
+			cmd.Printf("Native host: running (version %s)\n", info.HostVersion)
+			if err != nil {
+				cmd.Printf("Extension: not responding\n")
+				return err
+			}
+
+			cmd.Printf("Extension: responding (version %s)\n", info.ExtensionVersion)
+			cmd.Printf("Browser: %s\n", info.Browser)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -1,11 +1,13 @@
 package cmd
 
 import (
-	"encoding/json"
-	"os"
+	"fmt"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/pomdtr/webterm/webterm"
 	"github.com/spf13/cobra"
 )
 
@@ -14,6 +16,7 @@ type Download struct {
 	CanResume     bool   `json:"canResume"`
 	Danger        string `json:"danger"`
 	EndTime       string `json:"endTime"`
+	Error         string `json:"error,omitempty"`
 	Exists        bool   `json:"exists"`
 	FileSize      int    `json:"fileSize"`
 	Filename      string `json:"filename"`
@@ -29,6 +32,50 @@ type Download struct {
 	URL           string `json:"url"`
 }
 
+func fetchDownloads() ([]Download, error) {
+	res, err := sendMessage(map[string]string{
+		"command": "download.list",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var downloads []Download
+	if err := decodeResponse(res, &downloads); err != nil {
+		return nil, err
+	}
+
+	return downloads, nil
+}
+
+// downloadSize renders the size of a download, with its progress while in flight.
+func downloadSize(download Download) string {
+	if download.State != "in_progress" {
+		if download.FileSize > 0 {
+			return humanizeBytes(download.FileSize)
+		}
+		return humanizeBytes(download.BytesReceived)
+	}
+
+	if download.TotalBytes <= 0 {
+		return humanizeBytes(download.BytesReceived)
+	}
+
+	return fmt.Sprintf("%s / %s (%d%%)", humanizeBytes(download.BytesReceived), humanizeBytes(download.TotalBytes), download.BytesReceived*100/download.TotalBytes)
+}
+
+func renderDownloads(printer tableprinter.TablePrinter, downloads []Download) error {
+	for _, download := range downloads {
+		printer.AddField(strconv.Itoa(download.ID))
+		printer.AddField(download.Filename)
+		printer.AddField(download.State)
+		printer.AddField(downloadSize(download))
+		printer.EndRow()
+	}
+
+	return printer.Render()
+}
+
 func NewCmdDownloadList(printer tableprinter.TablePrinter) *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "list",
@@ -47,54 +94,155 @@ func NewCmdDownloadList(printer tableprinter.TablePrinter) *cobra.Command {
 				return nil
 			}
 
-			res, err := sendMessage(map[string]string{
-				"command": "download.list",
-			})
+			downloads, err := fetchDownloads()
 			if err != nil {
 				return err
 			}
 
-			var downloads []Download
-			if err := json.Unmarshal(res, &downloads); err != nil {
-				return err
-			}
-
 			outputJSON, _ := cmd.Flags().GetBool("json")
 			if outputJSON {
-				encoder := json.NewEncoder(os.Stdout)
-				encoder.SetIndent("", "  ")
-
-				if err := encoder.Encode(downloads); err != nil {
+				if err := writeJSON(cmd, downloads); err != nil {
 					return err
 				}
 				return nil
 			}
 
-			for _, download := range downloads {
-				printer.AddField(strconv.Itoa(download.ID))
-				printer.AddField(download.Filename)
-				printer.AddField(download.State)
-				printer.EndRow()
+			watch, _ := cmd.Flags().GetBool("watch")
+			if !watch {
+				return renderDownloads(printer, downloads)
 			}
 
-			if err := printer.Render(); err != nil {
-				return err
-			}
+			for {
+				// the printer accumulates rows, so each frame needs a fresh one
+				printer, err := newPrinter()
+				if err != nil {
+					return err
+				}
 
-			return nil
+				fmt.Print("\033[H\033[2J")
+				if err := renderDownloads(printer, downloads); err != nil {
+					return err
+				}
+
+				active := false
+				for _, download := range downloads {
+					if download.State == "in_progress" {
+						active = true
+						break
+					}
+				}
+
+				if !active {
+					return nil
+				}
+
+				if err := sleepContext(cmd.Context(), time.Second); err != nil {
+					return err
+				}
+				downloads, err = fetchDownloads()
+				if err != nil {
+					return err
+				}
+			}
 		},
 	}
 
 	cmd.Flags().Bool("json", false, "json output")
 	cmd.Flags().Bool("web", false, "open in browser")
+	cmd.Flags().Bool("watch", false, "follow active downloads until they finish")
 	return cmd
 }
 
+// getDownload returns the download with the given id.
+func getDownload(id int) (*Download, error) {
+	res, err := sendMessage(map[string]any{
+		"command": "download.get",
+		"id":      id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var downloads []Download
+	if err := decodeResponse(res, &downloads); err != nil {
+		return nil, err
+	}
+
+	if len(downloads) == 0 {
+		return nil, webterm.NotFoundf("no download with id: %d", id)
+	}
+
+	return &downloads[0], nil
+}
+
+// waitForDownload polls a download until it completes, printing its progress to stderr.
+func waitForDownload(cmd *cobra.Command, id int) error {
+	for {
+		download, err := getDownload(id)
+		if err != nil {
+			return err
+		}
+
+		switch download.State {
+		case "complete":
+			cmd.PrintErrf("Downloaded %s to %s\n", downloadSize(*download), download.Filename)
+			return nil
+		case "interrupted":
+			return fmt.Errorf("download %d was interrupted: %s", id, download.Error)
+		}
+
+		cmd.PrintErrf("Downloading %s\n", downloadSize(*download))
+		if err := sleepContext(cmd.Context(), 500*time.Millisecond); err != nil {
+			return err
+		}
+	}
+}
+
 func NewCmdDownload(printer tableprinter.TablePrinter) *cobra.Command {
 	cmd := &cobra.Command{
-		Use: "download",
+		Use:  "download [url]",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+
+			// the download runs in the browser, with its cookies, so the url must be absolute
+			if u, err := url.Parse(args[0]); err != nil || u.Scheme == "" {
+				return fmt.Errorf("invalid url: %s, expected an absolute url", args[0])
+			}
+
+			msg := map[string]any{
+				"command": "download.start",
+				"url":     args[0],
+			}
+			if filename, _ := cmd.Flags().GetString("filename"); filename != "" {
+				msg["filename"] = filename
+			}
+
+			res, err := sendMessage(msg)
+			if err != nil {
+				return err
+			}
+
+			var id int
+			if err := decodeResponse(res, &id); err != nil {
+				return err
+			}
+
+			fmt.Println(id)
+
+			if wait, _ := cmd.Flags().GetBool("wait"); wait {
+				return waitForDownload(cmd, id)
+			}
+
+			return nil
+		},
 	}
 
+	cmd.Flags().String("filename", "", "path of the file relative to the downloads directory")
+	cmd.Flags().Bool("wait", false, "wait for the download to complete")
+
 	cmd.AddCommand(NewCmdDownloadList(printer))
 
 	return cmd
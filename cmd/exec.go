@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// execScript runs javascript in a tab and returns the json value of its last expression.
+func execScript(tabId int, code string) (json.RawMessage, error) {
+	res, err := sendMessage(map[string]any{
+		"command": "tab.exec",
+		"tabId":   tabId,
+		"code":    code,
+	})
+	if err != nil {
+		return nil, explainScriptingError(err, "")
+	}
+
+	var result bytes.Buffer
+	if err := json.Compact(&result, res); err != nil {
+		return nil, fmt.Errorf("invalid script result: %w", err)
+	}
+
+	return json.RawMessage(result.Bytes()), nil
+}
+
+// readScript returns the script given inline, or the content of the script file.
+func readScript(script string, file string) (string, error) {
+	if file == "" {
+		return script, nil
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("unable to read script file: %w", err)
+	}
+
+	return string(content), nil
+}
+
+func NewCmdTabExec() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "exec <tabId> [code]",
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completeTabID,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tabId, err := resolveSingleTabID(args[0])
+			if err != nil {
+				return err
+			}
+
+			var code string
+			if len(args) > 1 {
+				code = args[1]
+			}
+
+			file, _ := cmd.Flags().GetString("file")
+			if code != "" && file != "" {
+				return fmt.Errorf("the code argument and --file are mutually exclusive")
+			}
+
+			code, err = readScript(code, file)
+			if err != nil {
+				return err
+			}
+			if code == "" {
+				return fmt.Errorf("no code given, pass it as an argument or with --file")
+			}
+
+			result, err := execScript(tabId, code)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+				return writeJSON(cmd, result)
+			}
+
+			var value any
+			if err := decodeResponse(result, &value); err != nil {
+				return err
+			}
+
+			switch value := value.(type) {
+			case nil:
+				// undefined and null print nothing
+			case string:
+				fmt.Println(value)
+			default:
+				fmt.Println(string(result))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("file", "", "file containing the code to run")
+
+	return cmd
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/pomdtr/webterm/webterm"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes of the webterm command, scripts can branch on them.
+const (
+	// ExitError is returned for any error not covered by the other codes.
+	ExitError = 1
+	// ExitUsage is returned when the command line is invalid: unknown commands or flags, wrong arguments.
+	ExitUsage = 2
+	// ExitNotConnected is returned when the native host or the extension can not be reached.
+	ExitNotConnected = 3
+	// ExitNotFound is returned when a tab, window or group does not exist.
+	ExitNotFound = 4
+	// ExitTimeout is returned when the browser does not answer in time.
+	ExitTimeout = 5
+	// ExitInterrupted is returned when the command is interrupted with Ctrl-C, as if it had not handled SIGINT.
+	ExitInterrupted = 130
+)
+
+// usageError marks an error caused by an invalid command line.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+// usageErrorPrefixes are the messages of the cobra usage errors that can not be wrapped.
+var usageErrorPrefixes = []string{
+	"unknown command",
+	"required flag(s)",
+	"if any flags in the group",
+}
+
+// ExitCode maps an error returned by Execute to the exit code of the process.
+func ExitCode(err error) int {
+	var usageErr *usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &usageErr):
+		return ExitUsage
+	case errors.Is(err, webterm.ErrNotConnected):
+		return ExitNotConnected
+	case errors.Is(err, webterm.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, webterm.ErrTimeout):
+		return ExitTimeout
+	case errors.Is(err, context.Canceled):
+		return ExitInterrupted
+	}
+
+	for _, prefix := range usageErrorPrefixes {
+		if strings.HasPrefix(err.Error(), prefix) {
+			return ExitUsage
+		}
+	}
+
+	return ExitError
+}
+
+// markUsageErrors wraps the flag and argument validation errors of cmd and its subcommands as usage errors.
+func markUsageErrors(cmd *cobra.Command) {
+	cmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &usageError{err: err}
+	})
+
+	var wrap func(cmd *cobra.Command)
+	wrap = func(cmd *cobra.Command) {
+		if validate := cmd.Args; validate != nil {
+			cmd.Args = func(cmd *cobra.Command, args []string) error {
+				if err := validate(cmd, args); err != nil {
+					return &usageError{err: err}
+				}
+				return nil
+			}
+		}
+
+		for _, sub := range cmd.Commands() {
+			wrap(sub)
+		}
+	}
+	wrap(cmd)
+}
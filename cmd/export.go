@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// writeMarkdownLinks writes the tabs as a markdown list of links.
+func writeMarkdownLinks(w io.Writer, tabs []Tab) error {
+	for _, tab := range tabs {
+		title := tab.Title
+		if title == "" {
+			title = tab.URL
+		}
+
+		// brackets in titles would end the link text early
+		title = strings.NewReplacer("[", `\[`, "]", `\]`).Replace(title)
+		if _, err := fmt.Fprintf(w, "- [%s](%s)\n", title, tab.URL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeBookmarkFile writes the tabs in the netscape bookmark format, which browsers can import.
+func writeBookmarkFile(w io.Writer, tabs []Tab) error {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE NETSCAPE-Bookmark-file-1>\n")
+	b.WriteString("<META HTTP-EQUIV=\"Content-Type\" CONTENT=\"text/html; charset=UTF-8\">\n")
+	b.WriteString("<TITLE>Bookmarks</TITLE>\n")
+	b.WriteString("<H1>Bookmarks</H1>\n")
+	b.WriteString("<DL><p>\n")
+	for _, tab := range tabs {
+		title := tab.Title
+		if title == "" {
+			title = tab.URL
+		}
+		fmt.Fprintf(&b, "    <DT><A HREF=\"%s\">%s</A>\n", html.EscapeString(tab.URL), html.EscapeString(title))
+	}
+	b.WriteString("</DL><p>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func NewCmdTabExport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "export",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			var write func(io.Writer, []Tab) error
+			switch format {
+			case "markdown":
+				write = writeMarkdownLinks
+			case "html", "netscape":
+				write = writeBookmarkFile
+			default:
+				return fmt.Errorf("invalid format: %s, expected markdown, html or netscape", format)
+			}
+
+			tabs, err := client.ListTabs(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			tabs, err = selectTabs(cmd, tabs)
+			if err != nil {
+				return err
+			}
+
+			output, _ := cmd.Flags().GetString("output")
+			if output == "" {
+				return write(os.Stdout, tabs)
+			}
+
+			var b strings.Builder
+			if err := write(&b, tabs); err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(output, []byte(b.String()), 0644); err != nil {
+				return fmt.Errorf("unable to write export file: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("format", "markdown", "export format: markdown, html or netscape")
+	cmd.Flags().StringP("output", "o", "", "write the export to this file instead of stdout")
+	addSelectFlags(cmd)
+
+	return cmd
+}
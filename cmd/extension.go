@@ -1,9 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
-	"os"
-
 	"github.com/cli/go-gh/v2/pkg/tableprinter"
 	"github.com/spf13/cobra"
 )
@@ -57,15 +54,13 @@ func NewCmdExtensionList(printer tableprinter.TablePrinter) *cobra.Command {
 			}
 
 			var extensions []BitwardenExtension
-			if err := json.Unmarshal(res, &extensions); err != nil {
+			if err := decodeResponse(res, &extensions); err != nil {
 				return err
 			}
 
 			outputJSON, _ := cmd.Flags().GetBool("json")
 			if outputJSON {
-				encoder := json.NewEncoder(os.Stdout)
-				encoder.SetIndent("", "  ")
-				if err := encoder.Encode(extensions); err != nil {
+				if err := writeJSON(cmd, extensions); err != nil {
 					return err
 				}
 				return nil
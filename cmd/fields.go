@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/publicsuffix"
+)
+
+// tabFields maps the json name of a tab field to its string value, including the derived fields of TabOutput.
+var tabFields = map[string]func(Tab) string{
+	"active":          func(t Tab) string { return strconv.FormatBool(t.Active) },
+	"audible":         func(t Tab) string { return strconv.FormatBool(t.Audible) },
+	"autoDiscardable": func(t Tab) string { return strconv.FormatBool(t.AutoDiscardable) },
+	"discarded":       func(t Tab) string { return strconv.FormatBool(t.Discarded) },
+	"domain":          func(t Tab) string { return registeredDomain(t.URL) },
+	"favIconUrl":      func(t Tab) string { return t.FavIconURL },
+	"groupId":         func(t Tab) string { return strconv.Itoa(t.GroupID) },
+	"height":          func(t Tab) string { return strconv.Itoa(t.Height) },
+	"highlighted":     func(t Tab) string { return strconv.FormatBool(t.Highlighted) },
+	"id":              func(t Tab) string { return strconv.Itoa(t.ID) },
+	"incognito":       func(t Tab) string { return strconv.FormatBool(t.Incognito) },
+	"index":           func(t Tab) string { return strconv.Itoa(t.Index) },
+	"lastAccessed":    func(t Tab) string { return strconv.FormatFloat(t.LastAccessed, 'f', -1, 64) },
+	"muted":           func(t Tab) string { return strconv.FormatBool(t.MutedInfo.Muted) },
+	"pinned":          func(t Tab) string { return strconv.FormatBool(t.Pinned) },
+	"selected":        func(t Tab) string { return strconv.FormatBool(t.Selected) },
+	"sessionId":       func(t Tab) string { return t.SessionID },
+	"status":          func(t Tab) string { return t.Status },
+	"title":           func(t Tab) string { return t.Title },
+	"url":             func(t Tab) string { return t.URL },
+	"width":           func(t Tab) string { return strconv.Itoa(t.Width) },
+	"windowId":        func(t Tab) string { return strconv.Itoa(t.WindowID) },
+}
+
+func tabFieldNames() []string {
+	names := make([]string, 0, len(tabFields))
+	for name := range tabFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// tabField returns the accessor of the named tab field.
+func tabField(name string) (func(Tab) string, error) {
+	field, ok := tabFields[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown field: %s, valid fields are: %s", name, strings.Join(tabFieldNames(), ", "))
+	}
+
+	return field, nil
+}
+
+// parseTabFields parses a comma-separated list of field names.
+func parseTabFields(spec string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		if _, err := tabField(name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no fields given")
+	}
+
+	return names, nil
+}
+
+// writeTabFields prints the given fields of each tab on a line, joined by the separator.
+func writeTabFields(w io.Writer, tabs []Tab, names []string, separator string, header bool) error {
+	if header {
+		if _, err := fmt.Fprintln(w, strings.Join(names, separator)); err != nil {
+			return err
+		}
+	}
+
+	values := make([]string, len(names))
+	for _, tab := range tabs {
+		for i, name := range names {
+			values[i] = tabFields[name](tab)
+		}
+
+		if _, err := fmt.Fprintln(w, strings.Join(values, separator)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// showHeader reports whether a header line is printed in the given format, set with --header or --no-header.
+// By default tables only get one on a terminal, while csv, tsv and --fields output always start with it.
+// Commands without the flags keep their headerless tables.
+func showHeader(cmd *cobra.Command, format string) bool {
+	if cmd.Flags().Lookup("header") == nil {
+		return format != "table"
+	}
+
+	if header, _ := cmd.Flags().GetBool("header"); header {
+		return true
+	}
+	if noHeader, _ := cmd.Flags().GetBool("no-header"); noHeader {
+		return false
+	}
+
+	return format != "table" || isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// addHeaderRow adds the upper-cased names as the first row of a table, in bold when colors are enabled.
+func addHeaderRow(cmd *cobra.Command, printer tableprinter.TablePrinter, names []string) {
+	var color func(string) string
+	if colorEnabled(cmd) {
+		color = boldColor
+	}
+
+	for _, name := range names {
+		printer.AddField(strings.ToUpper(name), tableprinter.WithColor(color))
+	}
+	printer.EndRow()
+}
+
+// addHeaderFlags adds the --header and --no-header flags read by showHeader.
+func addHeaderFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("header", false, "print a header line, the default for tables on a terminal and for csv, tsv and --fields output")
+	cmd.Flags().Bool("no-header", false, "do not print a header line")
+	cmd.MarkFlagsMutuallyExclusive("header", "no-header")
+}
+
+// defaultTabColumns are the fields shown when no columns are selected.
+var defaultTabColumns = []string{"id", "title", "url"}
+
+// tabOutputFormat returns the --format of a command, --json being a deprecated way to ask for json.
+// The format of the config is used when the flag is not set.
+func tabOutputFormat(cmd *cobra.Command) (string, error) {
+	format, _ := cmd.Flags().GetString("format")
+	if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+		if cmd.Flags().Changed("format") && format != "json" {
+			return "", fmt.Errorf("--json conflicts with --format=%s", format)
+		}
+		return "json", nil
+	}
+
+	if !cmd.Flags().Changed("format") {
+		if config.Format != "" {
+			format = config.Format
+		}
+	}
+
+	switch format {
+	case "":
+		return "table", nil
+	case "table", "json", "csv", "tsv":
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid format: %s, expected table, json, csv or tsv", format)
+	}
+}
+
+// writeTabRecords writes a record per tab as csv or tsv, preceded by a header of the field names.
+func writeTabRecords(w io.Writer, tabs []Tab, names []string, format string, header bool) error {
+	writer := csv.NewWriter(w)
+	if format == "tsv" {
+		writer.Comma = '\t'
+	}
+
+	if header {
+		if err := writer.Write(names); err != nil {
+			return err
+		}
+	}
+
+	record := make([]string, len(names))
+	for _, tab := range tabs {
+		for i, name := range names {
+			record[i] = tabFields[name](tab)
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// registeredDomain returns the registrable domain of a url, e.g. example.co.uk for www.example.co.uk.
+func registeredDomain(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+
+	domain, err := publicsuffix.EffectiveTLDPlusOne(u.Hostname())
+	if err != nil {
+		// ip addresses, localhost and public suffixes have no registered domain
+		return u.Hostname()
+	}
+
+	return domain
+}
+
+// TabOutput is the json output of a tab, augmented with fields derived from the browser ones.
+type TabOutput struct {
+	Tab
+	Domain string `json:"domain"`
+}
+
+func newTabOutput(tab Tab) TabOutput {
+	return TabOutput{
+		Tab:    tab,
+		Domain: registeredDomain(tab.URL),
+	}
+}
+
+func newTabOutputs(tabs []Tab) []TabOutput {
+	outputs := make([]TabOutput, len(tabs))
+	for i, tab := range tabs {
+		outputs[i] = newTabOutput(tab)
+	}
+
+	return outputs
+}
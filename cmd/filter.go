@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// SavedFilter maps flag names to the values they were set to.
+type SavedFilter map[string][]string
+
+func savedFiltersPath() string {
+	return filepath.Join(xdg.ConfigHome, "webterm", "filters.json")
+}
+
+func loadSavedFilters() (map[string]SavedFilter, error) {
+	filters := make(map[string]SavedFilter)
+	content, err := os.ReadFile(savedFiltersPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return filters, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &filters); err != nil {
+		return nil, fmt.Errorf("invalid saved filters file: %w", err)
+	}
+
+	return filters, nil
+}
+
+func writeSavedFilters(filters map[string]SavedFilter) error {
+	content, err := json.MarshalIndent(filters, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(savedFiltersPath()), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(savedFiltersPath(), content, 0644)
+}
+
+// String renders the filter as the flags it expands to.
+func (f SavedFilter) String() string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parts []string
+	for _, name := range names {
+		for _, value := range f[name] {
+			parts = append(parts, fmt.Sprintf("--%s=%s", name, value))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// applySavedFilters expands a --filter of the form @name into the flags of the saved filter, then records the
+// command's flags under the --save-filter name. Explicit flags win over saved ones.
+func applySavedFilters(cmd *cobra.Command) error {
+	load, _ := cmd.Flags().GetString("load-filter")
+	text, _ := cmd.Flags().GetString("filter")
+	save, _ := cmd.Flags().GetString("save-filter")
+
+	// any other --filter is the text to look for in the tabs, even when a saved filter has the same name
+	if name, ok := strings.CutPrefix(text, "@"); ok {
+		if load != "" {
+			return fmt.Errorf("--load-filter cannot be used with --filter @%s", name)
+		}
+
+		load = name
+		flag := cmd.Flags().Lookup("filter")
+		if err := flag.Value.Set(""); err != nil {
+			return err
+		}
+		flag.Changed = false
+	}
+
+	if load == "" && save == "" {
+		return nil
+	}
+
+	filters, err := loadSavedFilters()
+	if err != nil {
+		return err
+	}
+
+	if load != "" {
+		filter, ok := filters[load]
+		if !ok {
+			return fmt.Errorf("no saved filter named %s", load)
+		}
+
+		for name, values := range filter {
+			flag := cmd.Flags().Lookup(name)
+			if flag == nil {
+				return fmt.Errorf("saved filter %s uses unknown flag --%s", load, name)
+			}
+
+			if flag.Changed {
+				continue
+			}
+
+			for _, value := range values {
+				if err := cmd.Flags().Set(name, value); err != nil {
+					return fmt.Errorf("saved filter %s: %w", load, err)
+				}
+			}
+		}
+	}
+
+	if save != "" {
+		filter := make(SavedFilter)
+		cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+			if !flag.Changed || flag.Name == "save-filter" || flag.Name == "load-filter" {
+				return
+			}
+
+			if value, ok := flag.Value.(pflag.SliceValue); ok {
+				filter[flag.Name] = value.GetSlice()
+			} else {
+				filter[flag.Name] = []string{flag.Value.String()}
+			}
+		})
+
+		filters[save] = filter
+		if err := writeSavedFilters(filters); err != nil {
+			return fmt.Errorf("unable to save filter: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func NewCmdTabFilters(printer tableprinter.TablePrinter) *cobra.Command {
+	return &cobra.Command{
+		Use:  "filters",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filters, err := loadSavedFilters()
+			if err != nil {
+				return err
+			}
+
+			names := make([]string, 0, len(filters))
+			for name := range filters {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				printer.AddField(name)
+				printer.AddField(filters[name].String())
+				printer.EndRow()
+			}
+
+			if err := printer.Render(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const filterTabs = `[
+	{"id": 1, "windowId": 1, "index": 0, "title": "Go", "url": "https://go.dev", "groupId": -1},
+	{"id": 2, "windowId": 1, "index": 1, "title": "Radio", "url": "https://radio.example.com", "audible": true, "groupId": -1},
+	{"id": 3, "windowId": 1, "index": 2, "title": "sound design", "url": "https://example.com/sound", "groupId": -1}
+]`
+
+func TestSavedFilters(t *testing.T) {
+	tests := []struct {
+		name       string
+		saved      string
+		args       []string
+		wantStdout string
+		wantStderr string
+		wantErr    string
+	}{
+		{
+			name:       "text filter",
+			args:       []string{"--filter", "sound"},
+			wantStdout: "3\n",
+		},
+		{
+			name:       "saved filter",
+			saved:      `{"sound": {"audible": ["true"]}}`,
+			args:       []string{"--filter", "@sound"},
+			wantStdout: "2\n",
+		},
+		{
+			name:       "text filter with the name of a saved filter",
+			saved:      `{"sound": {"audible": ["true"]}}`,
+			args:       []string{"--filter", "sound"},
+			wantStdout: "3\n",
+		},
+		{
+			name:    "unknown saved filter",
+			saved:   `{"sound": {"audible": ["true"]}}`,
+			args:    []string{"--filter", "@music"},
+			wantErr: "no saved filter named music",
+		},
+		{
+			name:       "saved text filter",
+			saved:      `{"go": {"filter": ["go.dev"]}}`,
+			args:       []string{"--filter", "@go"},
+			wantStdout: "1\n",
+		},
+		{
+			name:       "explicit flags win",
+			saved:      `{"sound": {"audible": ["true"]}}`,
+			args:       []string{"--filter", "@sound", "--audible=false"},
+			wantStdout: "1\n2\n3\n",
+		},
+		{
+			name:       "deprecated load-filter",
+			saved:      `{"sound": {"audible": ["true"]}}`,
+			args:       []string{"--load-filter", "sound"},
+			wantStdout: "2\n",
+			wantStderr: "Flag --load-filter has been deprecated, use --filter @<name> instead\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := isolateConfig(t)
+			if tt.saved != "" {
+				if err := os.MkdirAll(filepath.Join(dir, "webterm"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, "webterm", "filters.json"), []byte(tt.saved), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			transport := &fakeTransport{responses: map[string]string{"tab.list": filterTabs}}
+			args := append([]string{"tab", "list", "--fields", "id", "--no-header"}, tt.args...)
+			stdout, stderr, err := runCommand(t, transport, args...)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+			}
+
+			if stdout != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", stdout, tt.wantStdout)
+			}
+			if stderr != tt.wantStderr {
+				t.Errorf("stderr = %q, want %q", stderr, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestSaveFilter(t *testing.T) {
+	dir := isolateConfig(t)
+
+	transport := &fakeTransport{responses: map[string]string{"tab.list": filterTabs}}
+	if _, stderr, err := runCommand(t, transport, "tab", "list", "--audible", "--save-filter", "sound"); err != nil {
+		t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "webterm", "filters.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), `"audible": [`) {
+		t.Errorf("saved filters = %s, want the audible flag", content)
+	}
+
+	stdout, stderr, err := runCommand(t, transport, "tab", "list", "--filter", "@sound", "--fields", "id", "--no-header")
+	if err != nil {
+		t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+	}
+	if stdout != "2\n" {
+		t.Errorf("stdout = %q, want the audible tab", stdout)
+	}
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdTabFind() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "find <query>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			caseSensitive, _ := cmd.Flags().GetBool("case-sensitive")
+			highlight, _ := cmd.Flags().GetBool("highlight")
+			msg := map[string]any{
+				"command":       "tab.find",
+				"query":         args[0],
+				"caseSensitive": caseSensitive,
+				"highlight":     highlight,
+			}
+			if cmd.Flags().Changed("tab") {
+				tabId, _ := cmd.Flags().GetInt("tab")
+				msg["tabId"] = tabId
+			}
+
+			res, err := sendMessage(msg)
+			if err != nil {
+				return explainScriptingError(err, "")
+			}
+
+			var count int
+			if err := decodeResponse(res, &count); err != nil {
+				return err
+			}
+
+			fmt.Println(count)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int("tab", 0, "id of the tab to search, defaults to the active tab")
+	cmd.Flags().Bool("case-sensitive", false, "match the query case-sensitively")
+	cmd.Flags().Bool("highlight", false, "leave the matches highlighted in the page")
+
+	return cmd
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+)
+
+// focusHistorySize caps the number of tabs remembered per profile.
+const focusHistorySize = 20
+
+func focusHistoryPath() string {
+	return filepath.Join(xdg.ConfigHome, "webterm", "focus-history.json")
+}
+
+func profileName() string {
+	if profile, ok := os.LookupEnv("WEBTERM_PROFILE"); ok && profile != "" {
+		return profile
+	}
+
+	return "default"
+}
+
+// loadFocusHistory returns the stack of previously focused tabs, most recent last.
+func loadFocusHistory() ([]int, error) {
+	content, err := os.ReadFile(focusHistoryPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var history map[string][]int
+	if err := json.Unmarshal(content, &history); err != nil {
+		return nil, err
+	}
+
+	return history[profileName()], nil
+}
+
+func saveFocusHistory(stack []int) error {
+	history := make(map[string][]int)
+	if content, err := os.ReadFile(focusHistoryPath()); err == nil {
+		if err := json.Unmarshal(content, &history); err != nil {
+			return err
+		}
+	}
+
+	if len(stack) > focusHistorySize {
+		stack = stack[len(stack)-focusHistorySize:]
+	}
+	history[profileName()] = stack
+
+	content, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(focusHistoryPath()), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(focusHistoryPath(), content, 0644)
+}
+
+// pushFocusHistory moves tabId to the top of the stack.
+func pushFocusHistory(stack []int, tabId int) []int {
+	pushed := make([]int, 0, len(stack)+1)
+	for _, id := range stack {
+		if id != tabId {
+			pushed = append(pushed, id)
+		}
+	}
+
+	return append(pushed, tabId)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdTabGoto() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "goto <url>",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeHistoryURLs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := normalizeURL(args[0])
+			if err != nil {
+				return err
+			}
+
+			ignoreQuery, _ := cmd.Flags().GetBool("ignore-query")
+			// the fragment only scrolls the page, a tab scrolled elsewhere is still a match
+			key := dedupeKey(target, ignoreQuery, true)
+
+			active, err := client.GetActiveTab(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if forceNew, _ := cmd.Flags().GetBool("new"); !forceNew {
+				tabs, err := client.ListAllTabs(cmd.Context())
+				if err != nil {
+					return err
+				}
+
+				for _, tab := range tabs {
+					if dedupeKey(tab.URL, ignoreQuery, true) != key {
+						continue
+					}
+
+					if err := client.FocusTab(cmd.Context(), tab.ID); err != nil {
+						return err
+					}
+
+					if tab.ID != active.ID {
+						if err := recordFocus(active.ID); err != nil {
+							return err
+						}
+					}
+
+					cmd.Printf("Focused tab %d: %s\n", tab.ID, tab.URL)
+					return nil
+				}
+			}
+
+			tabs, err := client.CreateTabs(cmd.Context(), []string{target}, nil)
+			if err != nil {
+				return err
+			}
+
+			if err := recordFocus(active.ID); err != nil {
+				return err
+			}
+
+			for _, tab := range tabs {
+				cmd.Printf("Created tab %d: %s\n", tab.ID, target)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("new", false, "create a new tab even if the url is already open")
+	cmd.Flags().Bool("ignore-query", false, "match open tabs regardless of their query string")
+
+	return cmd
+}
+
+// recordFocus pushes the tab losing the focus to the focus history, so that tab focus --back returns to it.
+func recordFocus(tabId int) error {
+	stack, err := loadFocusHistory()
+	if err != nil {
+		return fmt.Errorf("unable to read focus history: %w", err)
+	}
+
+	if err := saveFocusHistory(pushFocusHistory(stack, tabId)); err != nil {
+		return fmt.Errorf("unable to write focus history: %w", err)
+	}
+
+	return nil
+}
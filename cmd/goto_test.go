@@ -0,0 +1,50 @@
+package cmd
+
+import "testing"
+
+func TestTabGoto(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      string
+		tabs     string
+		wantSent []string
+	}{
+		{
+			name: "bare host matches the root of the site",
+			arg:  "github.com",
+			tabs: `[{"id": 1, "url": "https://go.dev/"}, {"id": 2, "url": "https://github.com/"}]`,
+			wantSent: []string{
+				`{"command": "tab.get"}`,
+				`{"command": "tab.list", "allWindows": true}`,
+				`{"command": "tab.focus", "tabId": 2}`,
+			},
+		},
+		{
+			name: "other path opens a tab",
+			arg:  "github.com/pomdtr",
+			tabs: `[{"id": 2, "url": "https://github.com/"}]`,
+			wantSent: []string{
+				`{"command": "tab.get"}`,
+				`{"command": "tab.list", "allWindows": true}`,
+				`{"command": "tab.create", "urls": ["https://github.com/pomdtr"]}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateConfig(t)
+
+			transport := &fakeTransport{responses: map[string]string{
+				"tab.get":    `{"id": 1}`,
+				"tab.list":   tt.tabs,
+				"tab.create": `[{"id": 3}]`,
+			}}
+			if _, stderr, err := runCommand(t, transport, "tab", "goto", tt.arg, "--quiet"); err != nil {
+				t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+			}
+
+			transport.assertSent(t, tt.wantSent...)
+		})
+	}
+}
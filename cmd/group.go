@@ -0,0 +1,532 @@
+package cmd
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/pomdtr/webterm/webterm"
+	"github.com/spf13/cobra"
+)
+
+type TabGroup = webterm.TabGroup
+
+func NewCmdTabGroupMove() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "move <groupId>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groupId, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid group id: %w", err)
+			}
+
+			group, err := client.GetTabGroup(cmd.Context(), groupId)
+			if err != nil {
+				return err
+			}
+
+			newWindow, _ := cmd.Flags().GetBool("new-window")
+			index, _ := cmd.Flags().GetInt("index")
+			opts := &webterm.MoveTabGroupOptions{NewWindow: newWindow}
+			if cmd.Flags().Changed("window") {
+				if newWindow {
+					return fmt.Errorf("--window and --new-window are mutually exclusive")
+				}
+				opts.WindowID, _ = cmd.Flags().GetInt("window")
+			}
+
+			moved, err := client.MoveTabGroup(cmd.Context(), groupId, index, opts)
+			if err != nil {
+				return err
+			}
+
+			// some browsers reset the group metadata when it crosses windows
+			if moved.WindowID != group.WindowID {
+				if err := client.UpdateTabGroup(cmd.Context(), moved.ID, webterm.TabGroupUpdate{
+					Title:     &group.Title,
+					Color:     &group.Color,
+					Collapsed: &group.Collapsed,
+				}); err != nil {
+					return fmt.Errorf("group moved but its metadata could not be restored: %w", err)
+				}
+			}
+
+			cmd.Printf("Moved group %d to window %d\n", moved.ID, moved.WindowID)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int("window", 0, "id of the destination window")
+	cmd.Flags().Bool("new-window", false, "move the group to a new window")
+	cmd.Flags().Int("index", -1, "position of the group in the window, -1 for the end")
+
+	return cmd
+}
+
+// groupColors is the palette the browser accepts for tab groups.
+var groupColors = []string{"grey", "blue", "red", "yellow", "green", "pink", "purple", "cyan", "orange"}
+
+func validateGroupColor(color string) error {
+	for _, c := range groupColors {
+		if c == color {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid color: %s, expected one of %s", color, strings.Join(groupColors, ", "))
+}
+
+// domainColor maps a domain to a group color, the same domain always gets the same color.
+func domainColor(domain string) string {
+	h := fnv.New32a()
+	h.Write([]byte(domain))
+	return groupColors[h.Sum32()%uint32(len(groupColors))]
+}
+
+func NewCmdTabOrganize() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "organize",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			byDomain, _ := cmd.Flags().GetBool("by-domain")
+			if !byDomain {
+				return fmt.Errorf("an organization strategy is required, e.g. --by-domain")
+			}
+
+			scheme, _ := cmd.Flags().GetString("color-scheme")
+			if scheme != "hash" && scheme != "sequential" && scheme != "none" {
+				return fmt.Errorf("invalid color scheme: %s, expected hash, sequential or none", scheme)
+			}
+
+			minTabs, _ := cmd.Flags().GetInt("min-tabs")
+
+			tabs, err := client.ListTabs(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			var domains []string
+			tabsByDomain := make(map[string][]int)
+			for _, tab := range tabs {
+				// pinned tabs can not be grouped
+				if tab.Pinned {
+					continue
+				}
+
+				// the same domain as the domain field, so docs.github.com and github.com share a group
+				domain := registeredDomain(tab.URL)
+				if domain == "" {
+					continue
+				}
+
+				if _, ok := tabsByDomain[domain]; !ok {
+					domains = append(domains, domain)
+				}
+				tabsByDomain[domain] = append(tabsByDomain[domain], tab.ID)
+			}
+
+			var created int
+			for _, domain := range domains {
+				tabIds := tabsByDomain[domain]
+				if len(tabIds) < minTabs {
+					continue
+				}
+
+				opts := &webterm.CreateTabGroupOptions{Title: domain}
+				switch scheme {
+				case "hash":
+					opts.Color = domainColor(domain)
+				case "sequential":
+					opts.Color = groupColors[created%len(groupColors)]
+				}
+
+				if _, err := client.CreateTabGroup(cmd.Context(), tabIds, opts); err != nil {
+					return err
+				}
+				created++
+			}
+
+			cmd.Printf("Created %d groups\n", created)
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("by-domain", false, "group tabs sharing a domain")
+	cmd.Flags().String("color-scheme", "hash", "group colors: hash (stable per domain), sequential or none")
+	cmd.Flags().Int("min-tabs", 2, "minimum number of tabs a domain needs to get a group")
+
+	return cmd
+}
+
+// parseGroupID parses a group id argument.
+func parseGroupID(arg string) (int, error) {
+	groupId, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, fmt.Errorf("invalid group id: %w", err)
+	}
+
+	return groupId, nil
+}
+
+func NewCmdTabGroupList(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "list",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groups, err := client.ListTabGroups(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			collapsed, _ := cmd.Flags().GetBool("collapsed")
+			expanded, _ := cmd.Flags().GetBool("expanded")
+			if collapsed || expanded {
+				filtered := []TabGroup{}
+				for _, group := range groups {
+					if group.Collapsed == collapsed {
+						filtered = append(filtered, group)
+					}
+				}
+				groups = filtered
+			}
+
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if jsonOutput {
+				return writeJSON(cmd, groups)
+			}
+
+			for _, group := range groups {
+				printer.AddField(strconv.Itoa(group.ID))
+				printer.AddField(group.Title)
+				printer.AddField(group.Color)
+				if group.Collapsed {
+					printer.AddField("collapsed")
+				} else {
+					printer.AddField("expanded")
+				}
+				printer.EndRow()
+			}
+
+			return printer.Render()
+		},
+	}
+
+	cmd.Flags().Bool("collapsed", false, "only list the collapsed groups")
+	cmd.Flags().Bool("expanded", false, "only list the expanded groups")
+	cmd.MarkFlagsMutuallyExclusive("collapsed", "expanded")
+
+	return cmd
+}
+
+func NewCmdTabGroupCreate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "create <tabId>...",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tabIds, err := resolveTabIDs(args)
+			if err != nil {
+				return err
+			}
+
+			title, _ := cmd.Flags().GetString("title")
+			color, _ := cmd.Flags().GetString("color")
+			if color != "" {
+				if err := validateGroupColor(color); err != nil {
+					return err
+				}
+			}
+
+			group, err := client.CreateTabGroup(cmd.Context(), tabIds, &webterm.CreateTabGroupOptions{
+				Title: title,
+				Color: color,
+			})
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+				return writeJSON(cmd, group)
+			}
+
+			fmt.Println(group.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("title", "", "title of the group")
+	cmd.Flags().String("color", "", "color of the group")
+
+	return cmd
+}
+
+func NewCmdTabGroupAdd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "add <groupId> <tabId>...",
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groupId, err := parseGroupID(args[0])
+			if err != nil {
+				return err
+			}
+
+			tabIds, err := resolveTabIDs(args[1:])
+			if err != nil {
+				return err
+			}
+
+			if err := client.AddToTabGroup(cmd.Context(), groupId, tabIds...); err != nil {
+				return err
+			}
+
+			printSummary(cmd, "grouped", len(tabIds), 0)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func NewCmdTabGroupRemove() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "remove <tabId>...",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tabIds, err := resolveTabIDs(args)
+			if err != nil {
+				return err
+			}
+
+			if err := client.UngroupTabs(cmd.Context(), tabIds...); err != nil {
+				return err
+			}
+
+			printSummary(cmd, "ungrouped", len(tabIds), 0)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// setGroupsCollapsed collapses or expands the group given as argument, or with --all every group of the current window
+// which is not already in that state.
+func setGroupsCollapsed(cmd *cobra.Command, args []string, collapsed bool) error {
+	all, _ := cmd.Flags().GetBool("all")
+	if all == (len(args) > 0) {
+		return fmt.Errorf("either a group id or --all is required")
+	}
+
+	var groupIds []int
+	if all {
+		window, err := client.GetFocusedWindow(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		groups, err := client.ListTabGroups(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		for _, group := range groups {
+			if group.WindowID == window.ID && group.Collapsed != collapsed {
+				groupIds = append(groupIds, group.ID)
+			}
+		}
+	} else {
+		groupId, err := parseGroupID(args[0])
+		if err != nil {
+			return err
+		}
+		groupIds = []int{groupId}
+	}
+
+	for _, groupId := range groupIds {
+		if err := client.UpdateTabGroup(cmd.Context(), groupId, webterm.TabGroupUpdate{Collapsed: &collapsed}); err != nil {
+			return err
+		}
+	}
+
+	if all {
+		verb := "Expanded"
+		if collapsed {
+			verb = "Collapsed"
+		}
+		cmd.Printf("%s %s\n", verb, pluralize(len(groupIds), "group"))
+	}
+
+	return nil
+}
+
+func NewCmdTabGroupCollapse() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "collapse [groupId]",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			expand, _ := cmd.Flags().GetBool("expand")
+			return setGroupsCollapsed(cmd, args, !expand)
+		},
+	}
+
+	cmd.Flags().Bool("expand", false, "expand the group instead")
+	cmd.Flags().Bool("all", false, "collapse every group of the current window")
+
+	return cmd
+}
+
+func NewCmdTabGroupExpand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "expand [groupId]",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setGroupsCollapsed(cmd, args, false)
+		},
+	}
+
+	cmd.Flags().Bool("all", false, "expand every group of the current window")
+
+	return cmd
+}
+
+func NewCmdTabGroupUngroup() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "ungroup <groupId>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groupId, err := parseGroupID(args[0])
+			if err != nil {
+				return err
+			}
+
+			tabs, err := client.QueryTabs(cmd.Context(), webterm.TabQuery{GroupID: groupId})
+			if err != nil {
+				return err
+			}
+
+			if len(tabs) == 0 {
+				return webterm.NotFoundf("no group with id: %d", groupId)
+			}
+
+			tabIds := make([]int, len(tabs))
+			for i, tab := range tabs {
+				tabIds[i] = tab.ID
+			}
+
+			// the browser removes a group once its last tab leaves it
+			if err := client.UngroupTabs(cmd.Context(), tabIds...); err != nil {
+				return err
+			}
+
+			printSummary(cmd, "ungrouped", len(tabIds), 0)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// findGroupByTitle returns the group with the given title, ignoring case, or nil when there is none.
+func findGroupByTitle(groups []TabGroup, title string) *TabGroup {
+	for i, group := range groups {
+		if group.Title == title {
+			return &groups[i]
+		}
+	}
+
+	for i, group := range groups {
+		if strings.EqualFold(group.Title, title) {
+			return &groups[i]
+		}
+	}
+
+	return nil
+}
+
+func NewCmdTabMoveToGroup() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "move-to-group <title> [tabId]...",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeTabIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			title := args[0]
+			if title == "" {
+				return fmt.Errorf("the group title can not be empty")
+			}
+
+			color, _ := cmd.Flags().GetString("color")
+			if color != "" {
+				if err := validateGroupColor(color); err != nil {
+					return err
+				}
+			}
+
+			var tabIds []int
+			if len(args) > 1 {
+				ids, err := resolveTabIDs(args[1:])
+				if err != nil {
+					return err
+				}
+				tabIds = ids
+			} else {
+				tab, err := client.GetActiveTab(cmd.Context())
+				if err != nil {
+					return err
+				}
+				tabIds = []int{tab.ID}
+			}
+
+			groups, err := client.ListTabGroups(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if group := findGroupByTitle(groups, title); group != nil {
+				if cmd.Flags().Changed("color") {
+					cmd.PrintErrf("Group %q already exists, ignoring --color\n", group.Title)
+				}
+
+				if err := client.AddToTabGroup(cmd.Context(), group.ID, tabIds...); err != nil {
+					return err
+				}
+
+				cmd.Printf("Moved %s to group %d: %s\n", pluralize(len(tabIds), "tab"), group.ID, group.Title)
+				return nil
+			}
+
+			group, err := client.CreateTabGroup(cmd.Context(), tabIds, &webterm.CreateTabGroupOptions{
+				Title: title,
+				Color: color,
+			})
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Moved %s to new group %d: %s\n", pluralize(len(tabIds), "tab"), group.ID, title)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("color", "", fmt.Sprintf("color of the group when it is created: %s", strings.Join(groupColors, ", ")))
+
+	return cmd
+}
+
+func NewCmdTabGroup(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "group",
+	}
+
+	cmd.AddCommand(NewCmdTabGroupList(printer))
+	cmd.AddCommand(NewCmdTabGroupCreate())
+	cmd.AddCommand(NewCmdTabGroupAdd())
+	cmd.AddCommand(NewCmdTabGroupRemove())
+	cmd.AddCommand(NewCmdTabGroupCollapse())
+	cmd.AddCommand(NewCmdTabGroupExpand())
+	cmd.AddCommand(NewCmdTabGroupUngroup())
+	cmd.AddCommand(NewCmdTabGroupMove())
+
+	return cmd
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import "testing"
+
+func TestTabGroupMove(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		responses map[string]string
+		wantSent  []string
+	}{
+		{
+			name: "to a new window restores the group metadata",
+			args: []string{"tab", "group", "move", "5", "--new-window"},
+			responses: map[string]string{
+				"tab.group.list": `[{"id": 5, "windowId": 1, "title": "docs", "color": "blue", "collapsed": true}]`,
+				"tab.group.move": `{"id": 5, "windowId": 3, "title": "", "color": "grey"}`,
+			},
+			wantSent: []string{
+				`{"command": "tab.group.list"}`,
+				`{"command": "tab.group.move", "groupId": 5, "index": -1, "newWindow": true}`,
+				`{"command": "tab.group.update", "groupId": 5, "title": "docs", "color": "blue", "collapsed": true}`,
+			},
+		},
+		{
+			name: "within its window leaves the group as is",
+			args: []string{"tab", "group", "move", "5", "--index", "0"},
+			responses: map[string]string{
+				"tab.group.list": `[{"id": 5, "windowId": 1, "title": "docs", "color": "blue"}]`,
+				"tab.group.move": `{"id": 5, "windowId": 1, "title": "docs", "color": "blue"}`,
+			},
+			wantSent: []string{
+				`{"command": "tab.group.list"}`,
+				`{"command": "tab.group.move", "groupId": 5, "index": 0}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateConfig(t)
+
+			transport := &fakeTransport{responses: tt.responses}
+			if _, stderr, err := runCommand(t, transport, append(tt.args, "--quiet")...); err != nil {
+				t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+			}
+
+			transport.assertSent(t, tt.wantSent...)
+		})
+	}
+}
+
+func TestTabOrganizeByDomain(t *testing.T) {
+	isolateConfig(t)
+
+	transport := &fakeTransport{responses: map[string]string{
+		"tab.list": `[
+			{"id": 1, "url": "https://docs.github.com/en"},
+			{"id": 2, "url": "https://www.github.com/pomdtr"},
+			{"id": 3, "url": "https://go.dev/"}
+		]`,
+		"tab.group.create": `{"id": 5, "title": "github.com"}`,
+	}}
+	if _, stderr, err := runCommand(t, transport, "tab", "organize", "--by-domain", "--color-scheme", "none", "--quiet"); err != nil {
+		t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+	}
+
+	transport.assertSent(t,
+		`{"command": "tab.list"}`,
+		`{"command": "tab.group.create", "tabIds": [1, 2], "title": "github.com"}`,
+	)
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// waitForTab polls a tab until it finishes loading or the timeout elapses, a zero timeout waits forever.
+func waitForTab(ctx context.Context, tabId int, interval time.Duration, timeout time.Duration) (*Tab, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		tab, err := client.GetTab(ctx, tabId)
+		if err != nil {
+			return nil, err
+		}
+
+		if tab.Status == "complete" {
+			return tab, nil
+		}
+
+		if timeout > 0 && time.Now().After(deadline) {
+			return nil, fmt.Errorf("tab %d did not finish loading after %s", tabId, timeout)
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// isErrorTab reports whether the tab shows a browser error page.
+func isErrorTab(ctx context.Context, tab Tab, titles []string) (bool, error) {
+	for _, title := range titles {
+		if strings.Contains(strings.ToLower(tab.Title), strings.ToLower(title)) {
+			return true, nil
+		}
+	}
+
+	return client.IsErrorTab(ctx, tab.ID)
+}
+
+func NewCmdTabHeal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "heal",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			titles, _ := cmd.Flags().GetStringArray("match-title")
+			retries, _ := cmd.Flags().GetInt("retries")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+			tabs, err := client.ListTabs(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			var broken []Tab
+			for _, tab := range tabs {
+				isError, err := isErrorTab(cmd.Context(), tab, titles)
+				if err != nil {
+					return err
+				}
+
+				if isError {
+					broken = append(broken, tab)
+				}
+			}
+
+			if dryRun {
+				for _, tab := range broken {
+					cmd.PrintErrf("Would reload tab %d: %s\n", tab.ID, tab.URL)
+				}
+				return nil
+			}
+
+			timeout, err := resolveTimeout(cmd)
+			if err != nil {
+				return err
+			}
+
+			var failed int
+			for _, tab := range broken {
+				healed := false
+				for attempt := 0; attempt <= retries && !healed; attempt++ {
+					if err := client.ReloadTabs(cmd.Context(), false, tab.ID); err != nil {
+						return err
+					}
+
+					reloaded, err := waitForTab(cmd.Context(), tab.ID, 500*time.Millisecond, timeout)
+					if err != nil {
+						continue
+					}
+
+					isError, err := isErrorTab(cmd.Context(), *reloaded, titles)
+					if err != nil {
+						return err
+					}
+					healed = !isError
+				}
+
+				if healed {
+					cmd.Printf("Reloaded tab %d: %s\n", tab.ID, tab.URL)
+				} else {
+					failed++
+					cmd.Printf("Tab %d is still broken after %d retries: %s\n", tab.ID, retries, tab.URL)
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d tabs could not be healed", failed)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArray("match-title", nil, "also treat tabs whose title contains this text as broken (repeatable)")
+	cmd.Flags().Int("retries", 2, "number of extra reloads for tabs that are still broken")
+	cmd.Flags().Bool("dry-run", false, "only print the tabs that would be reloaded")
+
+	return cmd
+}
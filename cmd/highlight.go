@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdTabHighlight() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "highlight <tabId>...",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeTabIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			windowId, _ := cmd.Flags().GetInt("window")
+
+			var indexes []int
+			if byIndex, _ := cmd.Flags().GetBool("index"); byIndex {
+				for _, arg := range args {
+					index, err := strconv.Atoi(arg)
+					if err != nil {
+						return fmt.Errorf("invalid tab index: %w", err)
+					}
+					indexes = append(indexes, index)
+				}
+			} else {
+				if cmd.Flags().Changed("window") {
+					return fmt.Errorf("--window can only be used with --index, tabs are highlighted in their own window")
+				}
+
+				tabIds, err := resolveTabIDs(args)
+				if err != nil {
+					return err
+				}
+
+				tabs, err := client.GetTabs(cmd.Context(), tabIds...)
+				if err != nil {
+					return err
+				}
+
+				// the browser highlights the tabs of a single window at a time
+				windowId = tabs[0].WindowID
+				for _, tab := range tabs {
+					if tab.WindowID != windowId {
+						return fmt.Errorf("tabs %d and %d are in different windows", tabs[0].ID, tab.ID)
+					}
+					indexes = append(indexes, tab.Index)
+				}
+			}
+
+			highlighted, err := client.HighlightTabs(cmd.Context(), windowId, indexes)
+			if err != nil {
+				return err
+			}
+
+			for _, tab := range highlighted {
+				fmt.Println(tab.ID)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("index", false, "the arguments are tab indexes instead of ids")
+	cmd.Flags().Int("window", 0, "with --index, the window of the tabs, defaults to the current one")
+
+	return cmd
+}
@@ -1,39 +1,240 @@
 package cmd
 
 import (
-	"os"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
 	"github.com/spf13/cobra"
 )
 
-func NewCmdHistorySearch() *cobra.Command {
-	return &cobra.Command{
-		Use:  "search",
+type HistoryItem struct {
+	ID            string  `json:"id"`
+	LastVisitTime float64 `json:"lastVisitTime"`
+	Title         string  `json:"title"`
+	TypedCount    int     `json:"typedCount"`
+	URL           string  `json:"url"`
+	VisitCount    int     `json:"visitCount"`
+}
+
+// historyCache memoizes history lookups for the duration of a completion request.
+var historyCache = make(map[string][]HistoryItem)
+
+func searchHistory(query string) ([]HistoryItem, error) {
+	if items, ok := historyCache[query]; ok {
+		return items, nil
+	}
+
+	res, err := sendMessage(map[string]any{
+		"command": "history.search",
+		"query":   query,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var items []HistoryItem
+	if err := decodeResponse(res, &items); err != nil {
+		return nil, err
+	}
+
+	historyCache[query] = items
+	return items, nil
+}
+
+// visitedRecently reports whether the url is part of the recent history, the browser
+// only searches the last 24 hours by default.
+func visitedRecently(url string) (bool, error) {
+	items, err := searchHistory(url)
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range items {
+		if item.URL == url {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// completeHistoryURLs suggests recently visited urls starting with the current word.
+func completeHistoryURLs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	items, err := searchHistory(toComplete)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	seen := make(map[string]bool)
+	var urls []string
+	for _, item := range items {
+		if seen[item.URL] || !strings.HasPrefix(item.URL, toComplete) {
+			continue
+		}
+		seen[item.URL] = true
+		urls = append(urls, fmt.Sprintf("%s\t%s", item.URL, item.Title))
+	}
+
+	return urls, cobra.ShellCompDirectiveNoFileComp
+}
+
+type Visit struct {
+	ID               string  `json:"id"`
+	ReferringVisitID string  `json:"referringVisitId"`
+	Transition       string  `json:"transition"`
+	VisitID          string  `json:"visitId"`
+	VisitTime        float64 `json:"visitTime"`
+}
+
+// defaultHistoryLimit caps history search results when --limit is not set, the history can hold years of visits.
+const defaultHistoryLimit = 100
+
+// parseSince parses a --since value, either a duration before now like 2h or 7d, or a date like 2023-06-01.
+func parseSince(value string) (time.Time, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return time.Now().AddDate(0, 0, -n), nil
+		}
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid --since value: %s, expected a duration like 2h or 7d, or a date like 2006-01-02", value)
+}
+
+func NewCmdHistorySearch(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "search [query]",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query, _ := cmd.Flags().GetString("query")
+			if len(args) > 0 {
+				if cmd.Flags().Changed("query") {
+					return fmt.Errorf("the query can not be given both as an argument and with --query")
+				}
+				query = args[0]
+			}
+
+			limit, _ := cmd.Flags().GetInt("limit")
+			if limit <= 0 {
+				return fmt.Errorf("--limit must be positive")
+			}
+
+			msg := map[string]any{
+				"command":    "history.search",
+				"query":      query,
+				"maxResults": limit,
+			}
+
+			// the browser only searches the last 24 hours without a start time
+			if since, _ := cmd.Flags().GetString("since"); since != "" {
+				start, err := parseSince(since)
+				if err != nil {
+					return err
+				}
+				msg["startTime"] = start.UnixMilli()
+			}
+
+			res, err := sendMessage(msg)
+			if err != nil {
+				return err
+			}
+
+			var items []HistoryItem
+			if err := decodeResponse(res, &items); err != nil {
+				return err
+			}
+
+			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+				if items == nil {
+					items = []HistoryItem{}
+				}
+				return writeJSON(cmd, items)
+			}
+
+			for _, item := range items {
+				printer.AddField(time.UnixMilli(int64(item.LastVisitTime)).Format(time.RFC3339))
+				printer.AddField(strconv.Itoa(item.VisitCount))
+				printer.AddField(item.Title)
+				printer.AddField(item.URL)
+				printer.EndRow()
+			}
+
+			return printer.Render()
+		},
+	}
+
+	cmd.Flags().String("query", "", "text to search in the urls and titles, all the history matches when empty")
+	cmd.Flags().String("since", "", "only search visits after this duration ago or date, e.g. 7d or 2006-01-02, defaults to 24h")
+	cmd.Flags().Int("limit", defaultHistoryLimit, "maximum number of entries to return")
+	cmd.Flags().Bool("json", false, "output as json")
+
+	return cmd
+}
+
+func NewCmdHistoryGet(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "get <url>",
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			query := args[0]
 			res, err := sendMessage(map[string]any{
-				"command": "history.search",
-				"query":   query,
+				"command": "history.getVisits",
+				"url":     args[0],
 			})
 			if err != nil {
 				return err
 			}
 
-			if _, err := os.Stdout.Write(res); err != nil {
+			var visits []Visit
+			if err := decodeResponse(res, &visits); err != nil {
+				return err
+			}
+
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if jsonOutput {
+				if err := writeJSON(cmd, visits); err != nil {
+					return err
+				}
+				return nil
+			}
+
+			for _, visit := range visits {
+				printer.AddField(time.UnixMilli(int64(visit.VisitTime)).Format(time.RFC3339))
+				printer.AddField(visit.Transition)
+				printer.EndRow()
+			}
+
+			if err := printer.Render(); err != nil {
 				return err
 			}
 
 			return nil
 		},
 	}
+
+	cmd.Flags().Bool("json", false, "output as json")
+
+	return cmd
 }
-func NewCmdHistory() *cobra.Command {
+
+func NewCmdHistory(printer tableprinter.TablePrinter) *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "history",
 	}
 
-	cmd.AddCommand(NewCmdHistorySearch())
+	cmd.AddCommand(NewCmdHistorySearch(printer))
+	cmd.AddCommand(NewCmdHistoryGet(printer))
 
 	return cmd
 }
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// compactJSON reports whether json should be output on a single line: --compact and --pretty win,
+// otherwise json is indented for terminals and compact when stdout is piped.
+func compactJSON(cmd *cobra.Command) bool {
+	if pretty, _ := cmd.Flags().GetBool("pretty"); pretty {
+		return false
+	}
+
+	if cmd.Flags().Changed("compact") {
+		compact, _ := cmd.Flags().GetBool("compact")
+		return compact
+	}
+
+	return !isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// marshalJSON encodes v, honoring the --compact, --pretty and --sort-keys flags.
+// The output ends with a newline.
+func marshalJSON(cmd *cobra.Command, v any) ([]byte, error) {
+	sortKeys, _ := cmd.Flags().GetBool("sort-keys")
+
+	if sortKeys {
+		// round-trip through generic values, maps are always encoded with sorted keys
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(b))
+		decoder.UseNumber()
+
+		var generic any
+		if err := decoder.Decode(&generic); err != nil {
+			return nil, err
+		}
+		v = generic
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	if !compactJSON(cmd) {
+		encoder.SetIndent("", "  ")
+	}
+
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeJSON encodes v to stdout, honoring the --compact, --pretty and --sort-keys flags.
+func writeJSON(cmd *cobra.Command, v any) error {
+	b, err := marshalJSON(cmd, v)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.Write(b)
+	return err
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// jsonCommand returns a command with the json output flags of the root command, set to the given arguments.
+func jsonCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("compact", false, "")
+	cmd.Flags().Bool("pretty", false, "")
+	cmd.Flags().Bool("sort-keys", false, "")
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+
+	return cmd
+}
+
+func TestMarshalJSONSortKeys(t *testing.T) {
+	type item struct {
+		Zebra int            `json:"zebra"`
+		Alpha string         `json:"alpha"`
+		Extra map[string]any `json:"extra"`
+	}
+	v := []item{{Zebra: 9007199254740993, Alpha: "a", Extra: map[string]any{"y": 1, "b": true}}}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "compact",
+			args: []string{"--sort-keys", "--compact"},
+			want: `[{"alpha":"a","extra":{"b":true,"y":1},"zebra":9007199254740993}]` + "\n",
+		},
+		{
+			name: "pretty",
+			args: []string{"--sort-keys", "--pretty"},
+			want: `[
+  {
+    "alpha": "a",
+    "extra": {
+      "b": true,
+      "y": 1
+    },
+    "zebra": 9007199254740993
+  }
+]
+`,
+		},
+		{
+			name: "struct order without sort-keys",
+			args: []string{"--compact"},
+			want: `[{"zebra":9007199254740993,"alpha":"a","extra":{"b":true,"y":1}}]` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the output must not depend on the run, e.g. on the map iteration order
+			for i := 0; i < 10; i++ {
+				b, err := marshalJSON(jsonCommand(t, tt.args...), v)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if string(b) != tt.want {
+					t.Fatalf("run %d: got %s, want %s", i, b, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestTabListSortKeysIsStable(t *testing.T) {
+	isolateConfig(t)
+
+	// the same tabs with their keys in another order
+	responses := []string{
+		`[{"id": 1, "url": "https://go.dev", "title": "Go", "groupId": -1}]`,
+		`[{"groupId": -1, "title": "Go", "url": "https://go.dev", "id": 1}]`,
+	}
+
+	var outputs []string
+	for _, res := range responses {
+		stdout, stderr, err := runCommand(t, &fakeTransport{responses: map[string]string{"tab.list": res}}, "tab", "list", "--format", "json", "--sort-keys", "--compact")
+		if err != nil {
+			t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+		}
+		outputs = append(outputs, stdout)
+	}
+
+	if outputs[0] != outputs[1] {
+		t.Errorf("outputs differ:\n%s\n%s", outputs[0], outputs[1])
+	}
+	if !strings.HasPrefix(outputs[0], `[{"active":false,"audible":false,`) {
+		t.Errorf("keys are not sorted: %s", outputs[0])
+	}
+}
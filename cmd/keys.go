@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// namedKeys are the key arguments of send-keys which are pressed instead of typed, by their lowercase name.
+var namedKeys = map[string]string{
+	"enter":      "Enter",
+	"tab":        "Tab",
+	"escape":     "Escape",
+	"esc":        "Escape",
+	"backspace":  "Backspace",
+	"delete":     "Delete",
+	"space":      " ",
+	"arrowup":    "ArrowUp",
+	"arrowdown":  "ArrowDown",
+	"arrowleft":  "ArrowLeft",
+	"arrowright": "ArrowRight",
+	"up":         "ArrowUp",
+	"down":       "ArrowDown",
+	"left":       "ArrowLeft",
+	"right":      "ArrowRight",
+	"home":       "Home",
+	"end":        "End",
+	"pageup":     "PageUp",
+	"pagedown":   "PageDown",
+}
+
+// keyModifiers are the accepted --modifiers.
+var keyModifiers = []string{"ctrl", "alt", "shift", "meta"}
+
+// parseKeyModifiers parses a comma-separated list of modifiers, e.g. ctrl,shift.
+func parseKeyModifiers(spec string) ([]string, error) {
+	var modifiers []string
+	for _, part := range strings.Split(spec, ",") {
+		modifier := strings.ToLower(strings.TrimSpace(part))
+		if modifier == "" {
+			continue
+		}
+
+		valid := false
+		for _, name := range keyModifiers {
+			if modifier == name {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid modifier: %s, expected one of %s", modifier, strings.Join(keyModifiers, ", "))
+		}
+
+		modifiers = append(modifiers, modifier)
+	}
+
+	return modifiers, nil
+}
+
+func NewCmdTabSendKeys() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "send-keys <keys>...",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			literal, _ := cmd.Flags().GetBool("literal")
+
+			// each argument is either a named key, such as Enter, or text to type
+			keys := make([]map[string]string, len(args))
+			for i, arg := range args {
+				if key, ok := namedKeys[strings.ToLower(arg)]; ok && !literal {
+					keys[i] = map[string]string{"key": key}
+					continue
+				}
+				keys[i] = map[string]string{"text": arg}
+			}
+
+			msg := map[string]any{
+				"command": "tab.sendKeys",
+				"keys":    keys,
+			}
+
+			if spec, _ := cmd.Flags().GetString("modifiers"); spec != "" {
+				modifiers, err := parseKeyModifiers(spec)
+				if err != nil {
+					return err
+				}
+				msg["modifiers"] = modifiers
+			}
+
+			if selector, _ := cmd.Flags().GetString("selector"); selector != "" {
+				msg["selector"] = selector
+			}
+
+			if cmd.Flags().Changed("tab") {
+				tabId, _ := cmd.Flags().GetInt("tab")
+				msg["tabId"] = tabId
+			}
+
+			if _, err := sendMessage(msg); err != nil {
+				return explainScriptingError(err, "")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int("tab", 0, "id of the tab to send the keys to, defaults to the active tab")
+	cmd.Flags().String("selector", "", "focus the first element matching the css selector before sending the keys")
+	cmd.Flags().String("modifiers", "", "comma-separated modifiers held while pressing the keys: ctrl, alt, shift or meta")
+	cmd.Flags().Bool("literal", false, "type every argument as text, even the ones naming a key such as Enter")
+
+	return cmd
+}
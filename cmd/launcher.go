@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/pomdtr/webterm/webterm"
+	"github.com/spf13/cobra"
+)
+
+// Launcher describes a shortcut written by tab export-launcher.
+type Launcher struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+	Icon  string `json:"icon,omitempty"`
+	Path  string `json:"path,omitempty"`
+}
+
+var slugRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slugify(s string) string {
+	slug := strings.Trim(slugRegexp.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if len(slug) > 60 {
+		slug = strings.TrimRight(slug[:60], "-")
+	}
+
+	return slug
+}
+
+func defaultLauncherFormat() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "webloc"
+	case "windows":
+		return "url"
+	default:
+		return "desktop"
+	}
+}
+
+// downloadFavicon saves the favicon of a tab next to the launchers and returns its path.
+func downloadFavicon(client *http.Client, iconURL string, dir string, name string) (string, error) {
+	if !strings.HasPrefix(iconURL, "http://") && !strings.HasPrefix(iconURL, "https://") {
+		return "", fmt.Errorf("unsupported favicon url: %s", iconURL)
+	}
+
+	res, err := client.Get(iconURL)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status fetching favicon: %s", res.Status)
+	}
+
+	ext := path.Ext(strings.SplitN(iconURL, "?", 2)[0])
+	if ext == "" {
+		ext = ".ico"
+	}
+
+	iconPath := filepath.Join(dir, "icons", name+ext)
+	if err := os.MkdirAll(filepath.Dir(iconPath), 0755); err != nil {
+		return "", err
+	}
+
+	f, err := os.Create(iconPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, res.Body); err != nil {
+		return "", err
+	}
+
+	return iconPath, nil
+}
+
+func launcherContent(format string, launcher Launcher) (string, error) {
+	switch format {
+	case "desktop":
+		content := fmt.Sprintf("[Desktop Entry]\nType=Link\nName=%s\nURL=%s\n", launcher.Title, launcher.URL)
+		if launcher.Icon != "" {
+			content += fmt.Sprintf("Icon=%s\n", launcher.Icon)
+		}
+		return content, nil
+	case "url":
+		content := fmt.Sprintf("[InternetShortcut]\r\nURL=%s\r\n", launcher.URL)
+		if launcher.Icon != "" {
+			content += fmt.Sprintf("IconFile=%s\r\nIconIndex=0\r\n", launcher.Icon)
+		}
+		return content, nil
+	case "webloc":
+		return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>URL</key>
+	<string>%s</string>
+</dict>
+</plist>
+`, html.EscapeString(launcher.URL)), nil
+	default:
+		return "", fmt.Errorf("unknown launcher format: %s", format)
+	}
+}
+
+func NewCmdTabExportLauncher() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "export-launcher",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, _ := cmd.Flags().GetString("output")
+			format, _ := cmd.Flags().GetString("format")
+			if format == "" {
+				format = defaultLauncherFormat()
+			}
+
+			if format != "json" {
+				if _, err := launcherContent(format, Launcher{}); err != nil {
+					return err
+				}
+			}
+
+			res, err := sendMessage(map[string]string{
+				"command": "tab.list",
+			})
+			if err != nil {
+				return err
+			}
+
+			tabs, err := webterm.DecodeTabs(res)
+			if err != nil {
+				return err
+			}
+
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("unable to create output directory: %w", err)
+			}
+
+			noIcons, _ := cmd.Flags().GetBool("no-icons")
+			client := &http.Client{Timeout: 10 * time.Second}
+			launchers := make([]Launcher, 0, len(tabs))
+			for _, tab := range tabs {
+				name := fmt.Sprintf("%s-%d", slugify(tab.Title), tab.ID)
+				launcher := Launcher{
+					Title: tab.Title,
+					URL:   tab.URL,
+				}
+
+				if !noIcons && tab.FavIconURL != "" {
+					icon, err := downloadFavicon(client, tab.FavIconURL, dir, name)
+					if err != nil {
+						cmd.PrintErrf("Skipping favicon for tab %d: %s\n", tab.ID, err)
+					} else {
+						launcher.Icon = icon
+					}
+				}
+
+				if format != "json" {
+					content, err := launcherContent(format, launcher)
+					if err != nil {
+						return err
+					}
+
+					launcher.Path = filepath.Join(dir, name+"."+format)
+					if err := os.WriteFile(launcher.Path, []byte(content), 0644); err != nil {
+						return fmt.Errorf("unable to write launcher: %w", err)
+					}
+				}
+
+				launchers = append(launchers, launcher)
+			}
+
+			if format == "json" {
+				content, err := json.MarshalIndent(launchers, "", "  ")
+				if err != nil {
+					return err
+				}
+
+				manifestPath := filepath.Join(dir, "launchers.json")
+				if err := os.WriteFile(manifestPath, append(content, '\n'), 0644); err != nil {
+					return fmt.Errorf("unable to write launcher manifest: %w", err)
+				}
+			}
+
+			cmd.Printf("Exported %d launchers to %s\n", len(launchers), dir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", ".", "directory to write the launchers to")
+	cmd.Flags().String("format", "", "launcher format: desktop, url, webloc or json (default depends on the os)")
+	cmd.Flags().Bool("no-icons", false, "do not download favicons")
+
+	return cmd
+}
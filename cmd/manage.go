@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
+	"github.com/mattn/go-runewidth"
+	"github.com/pomdtr/webterm/webterm"
+	"github.com/spf13/cobra"
+)
+
+const manageHelp = "enter focus • space select • x close • m mute • p pin • d duplicate • q quit"
+
+type tabsMsg []Tab
+
+type errMsg struct{ err error }
+
+type manageModel struct {
+	tabs     []Tab
+	cursor   int
+	offset   int
+	selected map[int]bool
+	width    int
+	height   int
+	err      error
+}
+
+func listTabs() tea.Msg {
+	res, err := sendMessage(map[string]string{
+		"command": "tab.list",
+	})
+	if err != nil {
+		return errMsg{err}
+	}
+
+	tabs, err := webterm.DecodeTabs(res)
+	if err != nil {
+		return errMsg{err}
+	}
+
+	return tabsMsg(tabs)
+}
+
+// runAction sends the given messages in order, then reloads the tab list.
+func runAction(msgs ...map[string]any) tea.Cmd {
+	return func() tea.Msg {
+		for _, msg := range msgs {
+			if _, err := sendMessage(msg); err != nil {
+				return errMsg{err}
+			}
+		}
+
+		return listTabs()
+	}
+}
+
+func (m manageModel) Init() tea.Cmd {
+	return listTabs
+}
+
+// targets returns the selected tabs, or the highlighted one if none are selected.
+func (m manageModel) targets() []Tab {
+	var tabs []Tab
+	for _, tab := range m.tabs {
+		if m.selected[tab.ID] {
+			tabs = append(tabs, tab)
+		}
+	}
+
+	if len(tabs) == 0 && m.cursor < len(m.tabs) {
+		tabs = append(tabs, m.tabs[m.cursor])
+	}
+
+	return tabs
+}
+
+// toggle splits the targets on the given state and sends onCommand or offCommand accordingly.
+func (m manageModel) toggle(state func(Tab) bool, onCommand string, offCommand string) tea.Cmd {
+	var on, off []int
+	for _, tab := range m.targets() {
+		if state(tab) {
+			off = append(off, tab.ID)
+		} else {
+			on = append(on, tab.ID)
+		}
+	}
+
+	var msgs []map[string]any
+	if len(on) > 0 {
+		msgs = append(msgs, map[string]any{"command": onCommand, "tabIds": on})
+	}
+	if len(off) > 0 {
+		msgs = append(msgs, map[string]any{"command": offCommand, "tabIds": off})
+	}
+
+	return runAction(msgs...)
+}
+
+func (m manageModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case tabsMsg:
+		m.tabs = msg
+		m.err = nil
+
+		ids := make(map[int]bool, len(m.tabs))
+		for _, tab := range m.tabs {
+			ids[tab.ID] = true
+		}
+		for id := range m.selected {
+			if !ids[id] {
+				delete(m.selected, id)
+			}
+		}
+
+		if m.cursor >= len(m.tabs) {
+			m.cursor = len(m.tabs) - 1
+		}
+		if m.cursor < 0 {
+			m.cursor = 0
+		}
+	case errMsg:
+		m.err = msg.err
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.tabs)-1 {
+				m.cursor++
+			}
+		case " ":
+			if m.cursor < len(m.tabs) {
+				id := m.tabs[m.cursor].ID
+				m.selected[id] = !m.selected[id]
+			}
+		case "enter":
+			if m.cursor < len(m.tabs) {
+				return m, runAction(map[string]any{
+					"command": "tab.focus",
+					"tabId":   m.tabs[m.cursor].ID,
+				})
+			}
+		case "x":
+			targets := m.targets()
+			if len(targets) == 0 {
+				return m, nil
+			}
+
+			tabIds := make([]int, len(targets))
+			for i, tab := range targets {
+				tabIds[i] = tab.ID
+			}
+			return m, runAction(map[string]any{
+				"command": "tab.remove",
+				"tabIds":  tabIds,
+			})
+		case "m":
+			return m, m.toggle(func(tab Tab) bool { return tab.MutedInfo.Muted }, "tab.mute", "tab.unmute")
+		case "p":
+			return m, m.toggle(func(tab Tab) bool { return tab.Pinned }, "tab.pin", "tab.unpin")
+		case "d":
+			var msgs []map[string]any
+			for _, tab := range m.targets() {
+				msgs = append(msgs, map[string]any{
+					"command": "tab.duplicate",
+					"tabId":   tab.ID,
+				})
+			}
+			return m, runAction(msgs...)
+		}
+	}
+
+	// keep the cursor inside the visible window
+	if rows := m.rows(); rows > 0 {
+		if m.cursor < m.offset {
+			m.offset = m.cursor
+		} else if m.cursor >= m.offset+rows {
+			m.offset = m.cursor - rows + 1
+		}
+	}
+
+	return m, nil
+}
+
+// rows is the number of tab rows that fit on screen, leaving room for the help and error lines.
+func (m manageModel) rows() int {
+	if m.height == 0 {
+		return len(m.tabs)
+	}
+
+	return m.height - 2
+}
+
+func (m manageModel) View() string {
+	var b strings.Builder
+
+	end := m.offset + m.rows()
+	if end > len(m.tabs) {
+		end = len(m.tabs)
+	}
+
+	for i := m.offset; i < end; i++ {
+		tab := m.tabs[i]
+
+		cursor := " "
+		if i == m.cursor {
+			cursor = ">"
+		}
+
+		check := "[ ]"
+		if m.selected[tab.ID] {
+			check = "[x]"
+		}
+
+		flags := []byte("   ")
+		if tab.Active {
+			flags[0] = '*'
+		}
+		if tab.Pinned {
+			flags[1] = 'P'
+		}
+		if tab.MutedInfo.Muted {
+			flags[2] = 'M'
+		}
+
+		line := fmt.Sprintf("%s %s %s %d %s  %s", cursor, check, flags, tab.ID, tab.Title, tab.URL)
+		if m.width > 0 {
+			line = runewidth.Truncate(line, m.width, "…")
+		}
+
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	if m.err != nil {
+		b.WriteString(fmt.Sprintf("error: %s\n", m.err))
+	} else {
+		b.WriteString("\n")
+	}
+	b.WriteString(manageHelp)
+
+	return b.String()
+}
+
+func NewCmdTabManage() *cobra.Command {
+	return &cobra.Command{
+		Use:  "manage",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !isatty.IsTerminal(os.Stdout.Fd()) || !isatty.IsTerminal(os.Stdin.Fd()) {
+				return fmt.Errorf("tab manage requires an interactive terminal")
+			}
+
+			p := tea.NewProgram(manageModel{
+				selected: make(map[int]bool),
+			}, tea.WithAltScreen())
+
+			if _, err := p.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
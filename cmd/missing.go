@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pomdtr/webterm/webterm"
+	"github.com/spf13/cobra"
+)
+
+// addOnMissingFlag adds the --on-missing flag read by onMissingPolicy.
+func addOnMissingFlag(cmd *cobra.Command) {
+	cmd.Flags().String("on-missing", "error", "what to do with the tab ids which do not exist: error, skip or warn")
+}
+
+// onMissingPolicy returns the --on-missing value of a command.
+func onMissingPolicy(cmd *cobra.Command) (string, error) {
+	policy, _ := cmd.Flags().GetString("on-missing")
+	switch policy {
+	case "error", "skip", "warn":
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid --on-missing value: %s, expected error, skip or warn", policy)
+	}
+}
+
+// skipMissingTab reports whether err is a missing tab skipped by --on-missing, warning about it with warn.
+func skipMissingTab(cmd *cobra.Command, tabId int, err error) bool {
+	if !errors.Is(err, webterm.ErrNotFound) {
+		return false
+	}
+
+	policy, _ := onMissingPolicy(cmd)
+	switch policy {
+	case "warn":
+		cmd.PrintErrf("Warning: tab %d not found, skipping\n", tabId)
+		return true
+	case "skip":
+		return true
+	default:
+		return false
+	}
+}
+
+// runOnTabs calls fn with the tab ids. The browser fails the whole call when one tab is missing,
+// so unless --on-missing is error the ids are checked against the open tabs and fn is called again
+// with the ones left. It returns the number of skipped tabs.
+func runOnTabs(cmd *cobra.Command, tabIds []int, fn func(tabIds []int) error) (int, error) {
+	err := fn(tabIds)
+	if len(tabIds) == 0 || !errors.Is(err, webterm.ErrNotFound) {
+		return 0, err
+	}
+
+	if policy, _ := onMissingPolicy(cmd); policy == "error" {
+		return 0, err
+	}
+
+	tabs, err := client.ListAllTabs(cmd.Context())
+	if err != nil {
+		return 0, err
+	}
+
+	open := make(map[int]bool)
+	for _, tab := range tabs {
+		open[tab.ID] = true
+	}
+
+	var found []int
+	for _, tabId := range tabIds {
+		if open[tabId] {
+			found = append(found, tabId)
+			continue
+		}
+		skipMissingTab(cmd, tabId, webterm.NotFoundf("no tab with id %d", tabId))
+	}
+
+	skipped := len(tabIds) - len(found)
+	if len(found) == 0 {
+		return skipped, nil
+	}
+
+	return skipped, fn(found)
+}
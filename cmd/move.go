@@ -0,0 +1,373 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/pomdtr/webterm/webterm"
+	"github.com/spf13/cobra"
+)
+
+// moveFixups restores the pinned state of moved tabs and reconciles their group membership:
+// tabs which ended up in another group leave it, unless keepGroup is set in which case they join
+// a group with the same title and color in the destination window, created if needed.
+// groups are the groups listed before the move, as moving the last tab of a group removes it.
+func moveFixups(ctx context.Context, before map[int]Tab, groups []TabGroup, moved []Tab, windowId int, keepGroup bool) error {
+	findGroup := func(id int) *TabGroup {
+		for i := range groups {
+			if groups[i].ID == id {
+				return &groups[i]
+			}
+		}
+		return nil
+	}
+
+	var repin, ungroup []int
+	regroup := make(map[int][]int)
+	var regroupOrder []int
+	for _, tab := range moved {
+		orig := before[tab.ID]
+		if orig.Pinned {
+			if !tab.Pinned {
+				repin = append(repin, tab.ID)
+			}
+			continue
+		}
+
+		if keepGroup && orig.GroupID != webterm.TabGroupNone {
+			if tab.GroupID == orig.GroupID {
+				continue
+			}
+			if tab.GroupID != webterm.TabGroupNone {
+				ungroup = append(ungroup, tab.ID)
+			}
+			if _, ok := regroup[orig.GroupID]; !ok {
+				regroupOrder = append(regroupOrder, orig.GroupID)
+			}
+			regroup[orig.GroupID] = append(regroup[orig.GroupID], tab.ID)
+			continue
+		}
+
+		// the browser adds a tab dropped between grouped tabs to their group
+		if tab.GroupID != webterm.TabGroupNone && tab.GroupID != orig.GroupID {
+			ungroup = append(ungroup, tab.ID)
+		}
+	}
+
+	if len(repin) > 0 {
+		if _, err := client.PinTabs(ctx, repin...); err != nil {
+			return fmt.Errorf("unable to restore pinned tabs: %w", err)
+		}
+	}
+
+	if len(ungroup) > 0 {
+		if err := client.UngroupTabs(ctx, ungroup...); err != nil {
+			return fmt.Errorf("unable to ungroup moved tabs: %w", err)
+		}
+	}
+
+	for _, groupId := range regroupOrder {
+		tabIds := regroup[groupId]
+		orig := findGroup(groupId)
+		if orig == nil {
+			return webterm.NotFoundf("no group with id: %d", groupId)
+		}
+
+		// without a destination window, tabs stay in the window of their group
+		destination := windowId
+		if destination == 0 {
+			destination = orig.WindowID
+		}
+
+		var target *TabGroup
+		for i, group := range groups {
+			if group.WindowID == destination && group.Title == orig.Title && group.Color == orig.Color {
+				target = &groups[i]
+				break
+			}
+		}
+
+		if target != nil {
+			if err := client.AddToTabGroup(ctx, target.ID, tabIds...); err != nil {
+				return fmt.Errorf("unable to restore group %s: %w", orig.Title, err)
+			}
+			continue
+		}
+
+		created, err := client.CreateTabGroup(ctx, tabIds, &webterm.CreateTabGroupOptions{
+			Title: orig.Title,
+			Color: orig.Color,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to restore group %s: %w", orig.Title, err)
+		}
+		groups = append(groups, *created)
+	}
+
+	return nil
+}
+
+// relativeMoveOffset returns the shift asked with --relative, --left or --right, and whether one of them was set.
+func relativeMoveOffset(cmd *cobra.Command) (int, bool, error) {
+	switch {
+	case cmd.Flags().Changed("relative"):
+		offset, _ := cmd.Flags().GetInt("relative")
+		return offset, true, nil
+	case cmd.Flags().Changed("left"):
+		return -1, true, nil
+	case cmd.Flags().Changed("right"):
+		return 1, true, nil
+	default:
+		return 0, false, nil
+	}
+}
+
+// moveTabsBy shifts each tab by offset positions within its window, clamped to the bounds of the window.
+// The tabs are moved one at a time, starting with the one closest to where they are heading,
+// so that a tab does not shift the position of the ones moved after it.
+func moveTabsBy(ctx context.Context, tabs []Tab, tabIds []int, offset int) ([]Tab, error) {
+	lastIndex := make(map[int]int)
+	byID := make(map[int]Tab, len(tabs))
+	for _, tab := range tabs {
+		byID[tab.ID] = tab
+		if tab.Index > lastIndex[tab.WindowID] {
+			lastIndex[tab.WindowID] = tab.Index
+		}
+	}
+
+	ordered := make([]Tab, len(tabIds))
+	for i, tabId := range tabIds {
+		ordered[i] = byID[tabId]
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if offset > 0 {
+			return ordered[i].Index > ordered[j].Index
+		}
+		return ordered[i].Index < ordered[j].Index
+	})
+
+	// tabs pushed against an edge of their window pile up next to each other
+	placed := make(map[int]int)
+	var moved []Tab
+	for _, tab := range ordered {
+		target := tab.Index + offset
+		if first := placed[tab.WindowID]; offset < 0 && target < first {
+			target = first
+		}
+		if last := lastIndex[tab.WindowID] - placed[tab.WindowID]; offset > 0 && target > last {
+			target = last
+		}
+		placed[tab.WindowID]++
+
+		tabs, err := client.MoveTabs(ctx, []int{tab.ID}, target, nil)
+		if err != nil {
+			return nil, err
+		}
+		moved = append(moved, tabs...)
+	}
+
+	return moved, nil
+}
+
+func NewCmdTabMove() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "move <tabId>...",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tabIds, err := resolveTabIDs(args)
+			if err != nil {
+				return err
+			}
+
+			windowId, _ := cmd.Flags().GetInt("window")
+			index, _ := cmd.Flags().GetInt("index")
+			keepGroup, _ := cmd.Flags().GetBool("keep-group")
+
+			tabs, err := client.ListAllTabs(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			before := make(map[int]Tab, len(tabs))
+			for _, tab := range tabs {
+				before[tab.ID] = tab
+			}
+
+			for _, tabId := range tabIds {
+				if _, ok := before[tabId]; !ok {
+					return webterm.NotFoundf("no tab with id: %d", tabId)
+				}
+			}
+
+			offset, relative, err := relativeMoveOffset(cmd)
+			if err != nil {
+				return err
+			}
+
+			var groups []TabGroup
+			if keepGroup {
+				groups, err = client.ListTabGroups(cmd.Context())
+				if err != nil {
+					return err
+				}
+			}
+
+			var moved []Tab
+			if relative {
+				moved, err = moveTabsBy(cmd.Context(), tabs, tabIds, offset)
+				if err != nil {
+					return err
+				}
+			} else {
+				moved, err = client.MoveTabs(cmd.Context(), tabIds, index, &webterm.MoveTabsOptions{WindowID: windowId})
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := moveFixups(cmd.Context(), before, groups, moved, windowId, keepGroup); err != nil {
+				return err
+			}
+
+			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+				return writeJSON(cmd, newTabOutputs(moved))
+			}
+
+			for _, tab := range moved {
+				cmd.Printf("Moved tab %d to index %d of window %d\n", tab.ID, tab.Index, tab.WindowID)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int("window", 0, "id of the destination window, defaults to the current window of the tabs")
+	cmd.Flags().Int("index", -1, "position of the tabs in the window, -1 for the end")
+	cmd.Flags().Bool("keep-group", false, "place grouped tabs in a matching group of the destination window")
+	cmd.Flags().Int("relative", 0, "shift the tabs by this many positions within their window, negative values move them left")
+	cmd.Flags().Bool("left", false, "shift the tabs one position to the left, same as --relative=-1")
+	cmd.Flags().Bool("right", false, "shift the tabs one position to the right, same as --relative=1")
+	cmd.MarkFlagsMutuallyExclusive("relative", "left", "right", "index")
+	cmd.MarkFlagsMutuallyExclusive("relative", "window")
+	cmd.MarkFlagsMutuallyExclusive("left", "window")
+	cmd.MarkFlagsMutuallyExclusive("right", "window")
+
+	return cmd
+}
+
+func NewCmdTabMoveAllToWindow() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "move-all-to-window [windowId] [tabId]...",
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			newWindow, _ := cmd.Flags().GetBool("new")
+			var windowId int
+			if !newWindow {
+				if len(args) == 0 {
+					return fmt.Errorf("a window id or --new is required")
+				}
+
+				id, err := strconv.Atoi(args[0])
+				if err != nil {
+					return fmt.Errorf("invalid window id: %w", err)
+				}
+				windowId = id
+				args = args[1:]
+			}
+
+			tabs, err := client.ListAllTabs(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			before := make(map[int]Tab, len(tabs))
+			for _, tab := range tabs {
+				before[tab.ID] = tab
+			}
+
+			var sources []Tab
+			if len(args) > 0 {
+				tabIds, err := resolveTabIDs(args)
+				if err != nil {
+					return err
+				}
+
+				for _, tabId := range tabIds {
+					tab, ok := before[tabId]
+					if !ok {
+						return webterm.NotFoundf("no tab with id: %d", tabId)
+					}
+					sources = append(sources, tab)
+				}
+			} else {
+				sources, err = selectTabs(cmd, tabs)
+				if err != nil {
+					return err
+				}
+			}
+
+			// the tabs already in the window stay where they are
+			var moving []Tab
+			for _, tab := range sources {
+				if tab.WindowID != windowId {
+					moving = append(moving, tab)
+				}
+			}
+
+			// pinned tabs go first so that they end up in the pinned area, the others keep their order
+			sort.SliceStable(moving, func(i, j int) bool {
+				a, b := moving[i], moving[j]
+				if a.Pinned != b.Pinned {
+					return a.Pinned
+				}
+				return tabSorts["index"](a, b)
+			})
+
+			if len(moving) == 0 {
+				printSummary(cmd, "moved", 0, len(sources))
+				return nil
+			}
+
+			tabIds := make([]int, len(moving))
+			for i, tab := range moving {
+				tabIds[i] = tab.ID
+			}
+
+			// a new window is opened with the first tab, so that it does not get an extra empty tab
+			rest := tabIds
+			if newWindow {
+				window, err := client.CreateWindow(cmd.Context(), nil, &webterm.CreateWindowOptions{TabID: tabIds[0]})
+				if err != nil {
+					return err
+				}
+				windowId = window.ID
+				rest = tabIds[1:]
+			}
+
+			if len(rest) > 0 {
+				if _, err := client.MoveTabs(cmd.Context(), rest, -1, &webterm.MoveTabsOptions{WindowID: windowId}); err != nil {
+					return err
+				}
+			}
+
+			moved, err := client.GetTabs(cmd.Context(), tabIds...)
+			if err != nil {
+				return err
+			}
+
+			if err := moveFixups(cmd.Context(), before, nil, moved, windowId, false); err != nil {
+				return err
+			}
+
+			printSummary(cmd, "moved", len(moving), len(sources)-len(moving))
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("new", false, "move the tabs to a new window")
+	addFilterFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import "testing"
+
+func TestTabMoveFixups(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		responses map[string]string
+		wantSent  []string
+	}{
+		{
+			name: "pinned tab stays pinned",
+			args: []string{"tab", "move", "1", "--window", "2"},
+			responses: map[string]string{
+				"tab.list": `[{"id": 1, "windowId": 1, "index": 0, "pinned": true, "groupId": -1}]`,
+				"tab.move": `[{"id": 1, "windowId": 2, "index": 3, "groupId": -1}]`,
+			},
+			wantSent: []string{
+				`{"command": "tab.list", "allWindows": true}`,
+				`{"command": "tab.move", "tabIds": [1], "index": -1, "windowId": 2}`,
+				`{"command": "tab.pin", "tabIds": [1]}`,
+			},
+		},
+		{
+			name: "tab moved within its group keeps it",
+			args: []string{"tab", "move", "1", "--index", "2"},
+			responses: map[string]string{
+				"tab.list": `[{"id": 1, "windowId": 1, "index": 0, "groupId": 5}]`,
+				"tab.move": `[{"id": 1, "windowId": 1, "index": 2, "groupId": 5}]`,
+			},
+			wantSent: []string{
+				`{"command": "tab.list", "allWindows": true}`,
+				`{"command": "tab.move", "tabIds": [1], "index": 2}`,
+			},
+		},
+		{
+			name: "tab dropped into another group leaves it",
+			args: []string{"tab", "move", "1", "--window", "2", "--index", "1"},
+			responses: map[string]string{
+				"tab.list": `[{"id": 1, "windowId": 1, "index": 0, "groupId": 5}]`,
+				"tab.move": `[{"id": 1, "windowId": 2, "index": 1, "groupId": 7}]`,
+			},
+			wantSent: []string{
+				`{"command": "tab.list", "allWindows": true}`,
+				`{"command": "tab.move", "tabIds": [1], "index": 1, "windowId": 2}`,
+				`{"command": "tab.ungroup", "tabIds": [1]}`,
+			},
+		},
+		{
+			name: "keep group recreates the group of its last tab",
+			args: []string{"tab", "move", "1", "--window", "2", "--keep-group"},
+			responses: map[string]string{
+				"tab.list":         `[{"id": 1, "windowId": 1, "index": 0, "groupId": 5}]`,
+				"tab.group.list":   `[{"id": 5, "windowId": 1, "title": "docs", "color": "blue"}]`,
+				"tab.move":         `[{"id": 1, "windowId": 2, "index": 0, "groupId": -1}]`,
+				"tab.group.create": `{"id": 8, "windowId": 2, "title": "docs", "color": "blue"}`,
+			},
+			wantSent: []string{
+				`{"command": "tab.list", "allWindows": true}`,
+				`{"command": "tab.group.list"}`,
+				`{"command": "tab.move", "tabIds": [1], "index": -1, "windowId": 2}`,
+				`{"command": "tab.group.create", "tabIds": [1], "title": "docs", "color": "blue"}`,
+			},
+		},
+		{
+			name: "keep group joins the matching group of the window",
+			args: []string{"tab", "move", "1", "--window", "2", "--keep-group"},
+			responses: map[string]string{
+				"tab.list": `[{"id": 1, "windowId": 1, "index": 0, "groupId": 5}]`,
+				"tab.group.list": `[
+					{"id": 5, "windowId": 1, "title": "docs", "color": "blue"},
+					{"id": 6, "windowId": 2, "title": "docs", "color": "red"},
+					{"id": 9, "windowId": 2, "title": "docs", "color": "blue"}
+				]`,
+				"tab.move": `[{"id": 1, "windowId": 2, "index": 0, "groupId": 6}]`,
+			},
+			wantSent: []string{
+				`{"command": "tab.list", "allWindows": true}`,
+				`{"command": "tab.group.list"}`,
+				`{"command": "tab.move", "tabIds": [1], "index": -1, "windowId": 2}`,
+				`{"command": "tab.ungroup", "tabIds": [1]}`,
+				`{"command": "tab.group.add", "tabIds": [1], "groupId": 9}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateConfig(t)
+
+			transport := &fakeTransport{responses: tt.responses}
+			if _, stderr, err := runCommand(t, transport, append(tt.args, "--quiet")...); err != nil {
+				t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+			}
+
+			transport.assertSent(t, tt.wantSent...)
+		})
+	}
+}
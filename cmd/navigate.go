@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdTabNavigate(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "navigate [url]",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			back, _ := cmd.Flags().GetBool("back")
+			forward, _ := cmd.Flags().GetBool("forward")
+			if (len(args) > 0) == (back || forward) {
+				return fmt.Errorf("either a url, --back or --forward is required")
+			}
+
+			var tabId int
+			if cmd.Flags().Changed("tab") {
+				tabId, _ = cmd.Flags().GetInt("tab")
+			} else {
+				tab, err := client.GetActiveTab(cmd.Context())
+				if err != nil {
+					return err
+				}
+				tabId = tab.ID
+			}
+
+			msg := map[string]any{
+				"tabId": tabId,
+			}
+
+			switch {
+			case back:
+				msg["command"] = "tab.goBack"
+			case forward:
+				msg["command"] = "tab.goForward"
+			default:
+				url, err := normalizeURL(args[0])
+				if err != nil {
+					return err
+				}
+				msg["command"] = "tab.update"
+				msg["url"] = url
+			}
+
+			timeout, err := resolveTimeout(cmd)
+			if err != nil {
+				return err
+			}
+
+			if _, err := sendMessage(msg); err != nil {
+				return err
+			}
+
+			tab, err := waitForTab(cmd.Context(), tabId, 250*time.Millisecond, timeout)
+			if err != nil {
+				return err
+			}
+
+			return writeTab(cmd, printer, *tab)
+		},
+	}
+
+	cmd.Flags().Int("tab", 0, "id of the tab to navigate, defaults to the active tab")
+	cmd.Flags().Bool("back", false, "go back in the tab history")
+	cmd.Flags().Bool("forward", false, "go forward in the tab history")
+	cmd.MarkFlagsMutuallyExclusive("back", "forward")
+	cmd.Flags().String("format", "table", "output format: table, json, csv or tsv")
+
+	return cmd
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTabNavigateFormat(t *testing.T) {
+	isolateConfig(t)
+
+	transport := &fakeTransport{responses: map[string]string{
+		"tab.get": `{"id": 2, "url": "https://go.dev/", "status": "complete"}`,
+	}}
+	stdout, stderr, err := runCommand(t, transport, "tab", "navigate", "https://go.dev", "--tab", "2", "--format", "json", "--timeout", "1s")
+	if err != nil {
+		t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+	}
+
+	transport.assertSent(t,
+		`{"command": "tab.update", "tabId": 2, "url": "https://go.dev"}`,
+		`{"command": "tab.get", "tabId": 2}`,
+	)
+	if !strings.Contains(stdout, `"domain":"go.dev"`) {
+		t.Errorf("stdout = %q, want the tab as json", stdout)
+	}
+}
+
+func TestTabWaitUsesTheGlobalTimeout(t *testing.T) {
+	isolateConfig(t)
+
+	transport := &fakeTransport{responses: map[string]string{
+		"tab.get": `{"id": 2, "status": "loading"}`,
+	}}
+	_, _, err := runCommand(t, transport, "tab", "wait", "2", "--timeout", "100ms", "--interval", "10ms")
+	if err == nil || !strings.Contains(err.Error(), "100ms") {
+		t.Errorf("got error %v, want a timeout after 100ms", err)
+	}
+}
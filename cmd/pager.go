@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// pager pipes output through an external pager process.
+type pager struct {
+	cmd *exec.Cmd
+	in  io.WriteCloser
+}
+
+func (p *pager) Write(b []byte) (int, error) {
+	return p.in.Write(b)
+}
+
+// Close waits for the user to quit the pager.
+func (p *pager) Close() error {
+	p.in.Close()
+	return p.cmd.Wait()
+}
+
+// startPager starts $PAGER, or less when PAGER is not set.
+func startPager() (*pager, error) {
+	command := os.Getenv("PAGER")
+	if command == "" {
+		command = "less -FRX"
+	}
+
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, exec.ErrNotFound
+	}
+
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(path, args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	return &pager{cmd: cmd, in: in}, nil
+}
+
+// pagedPrinter returns a printer writing to a pager when the given number of rows
+// does not fit in the terminal, or when paging is forced with --pager.
+// The returned function must be called once the printer has been rendered.
+func pagedPrinter(cmd *cobra.Command, printer tableprinter.TablePrinter, rows int) (tableprinter.TablePrinter, func() error) {
+	noop := func() error { return nil }
+
+	if noPager, _ := cmd.Flags().GetBool("no-pager"); noPager {
+		return printer, noop
+	}
+
+	if !isatty.IsTerminal(os.Stdout.Fd()) {
+		return printer, noop
+	}
+
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return printer, noop
+	}
+
+	if force, _ := cmd.Flags().GetBool("pager"); !force && rows <= height {
+		return printer, noop
+	}
+
+	p, err := startPager()
+	if err != nil {
+		return printer, noop
+	}
+
+	return tableprinter.New(p, true, width), p.Close
+}
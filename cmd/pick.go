@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/mattn/go-isatty"
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+)
+
+const pickHelp = "type to filter • ↑/↓ move • enter pick • esc quit"
+
+type pickModel struct {
+	tabs    []Tab
+	query   string
+	matches []Tab
+	cursor  int
+	offset  int
+	width   int
+	height  int
+	picked  *Tab
+}
+
+// fuzzyMatch reports whether the runes of query appear in order in s, ignoring case.
+func fuzzyMatch(query string, s string) bool {
+	s = strings.ToLower(s)
+	for _, r := range strings.ToLower(query) {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(r)):]
+	}
+
+	return true
+}
+
+// filter updates the matches from the query, keeping the cursor on the list.
+func (m *pickModel) filter() {
+	m.matches = m.matches[:0]
+	for _, tab := range m.tabs {
+		if fuzzyMatch(m.query, tab.Title+" "+tab.URL) {
+			m.matches = append(m.matches, tab)
+		}
+	}
+
+	m.cursor = 0
+	m.offset = 0
+}
+
+func (m pickModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m pickModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc, tea.KeyCtrlC:
+			return m, tea.Quit
+		case tea.KeyEnter:
+			if m.cursor < len(m.matches) {
+				m.picked = &m.matches[m.cursor]
+			}
+			return m, tea.Quit
+		case tea.KeyUp, tea.KeyCtrlP:
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case tea.KeyDown, tea.KeyCtrlN:
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+		case tea.KeyBackspace:
+			if m.query != "" {
+				runes := []rune(m.query)
+				m.query = string(runes[:len(runes)-1])
+				m.filter()
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			m.query += string(msg.Runes)
+			m.filter()
+		}
+	}
+
+	// keep the cursor inside the visible window
+	if rows := m.rows(); rows > 0 {
+		if m.cursor < m.offset {
+			m.offset = m.cursor
+		} else if m.cursor >= m.offset+rows {
+			m.offset = m.cursor - rows + 1
+		}
+	}
+
+	return m, nil
+}
+
+// rows is the number of tab rows that fit on screen, leaving room for the prompt and help lines.
+func (m pickModel) rows() int {
+	if m.height == 0 {
+		return len(m.matches)
+	}
+
+	return m.height - 3
+}
+
+func (m pickModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("> %s\n", m.query))
+
+	end := m.offset + m.rows()
+	if end > len(m.matches) {
+		end = len(m.matches)
+	}
+
+	for i := m.offset; i < end; i++ {
+		tab := m.matches[i]
+
+		cursor := " "
+		if i == m.cursor {
+			cursor = ">"
+		}
+
+		line := fmt.Sprintf("%s %d %s  %s", cursor, tab.ID, tab.Title, tab.URL)
+		if m.width > 0 {
+			line = runewidth.Truncate(line, m.width, "…")
+		}
+
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString(fmt.Sprintf("\n%d/%d • %s", len(m.matches), len(m.tabs), pickHelp))
+
+	return b.String()
+}
+
+func NewCmdTabPick(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "pick",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			printID, _ := cmd.Flags().GetBool("print")
+
+			tabs, err := client.ListTabs(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			// with --print the picker is drawn on stderr, so that the id can be captured
+			output := os.Stdout
+			if printID {
+				output = os.Stderr
+			}
+
+			if !isatty.IsTerminal(output.Fd()) || !isatty.IsTerminal(os.Stdin.Fd()) {
+				for _, tab := range tabs {
+					printer.AddField(strconv.Itoa(tab.ID))
+					printer.AddField(tab.Title)
+					printer.AddField(tab.URL)
+					printer.EndRow()
+				}
+
+				return printer.Render()
+			}
+
+			model := pickModel{
+				tabs: tabs,
+			}
+			model.filter()
+
+			res, err := tea.NewProgram(model, tea.WithAltScreen(), tea.WithOutput(output)).Run()
+			if err != nil {
+				return err
+			}
+
+			picked := res.(pickModel).picked
+			if picked == nil {
+				return nil
+			}
+
+			if printID {
+				fmt.Println(picked.ID)
+				return nil
+			}
+
+			return client.FocusTab(cmd.Context(), picked.ID)
+		},
+	}
+
+	cmd.Flags().Bool("print", false, "print the id of the picked tab instead of focusing it")
+
+	return cmd
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/adrg/xdg"
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/spf13/cobra"
+)
+
+// WindowPreset is a saved window geometry, the dimensions only apply to normal windows.
+type WindowPreset struct {
+	State  string `json:"state"`
+	Left   int    `json:"left,omitempty"`
+	Top    int    `json:"top,omitempty"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+func (p WindowPreset) String() string {
+	if p.State != "normal" {
+		return p.State
+	}
+
+	return fmt.Sprintf("%dx%d+%d+%d", p.Width, p.Height, p.Left, p.Top)
+}
+
+func windowPresetsPath() string {
+	return filepath.Join(xdg.ConfigHome, "webterm", "window-presets.json")
+}
+
+func loadWindowPresets() (map[string]WindowPreset, error) {
+	presets := make(map[string]WindowPreset)
+	content, err := os.ReadFile(windowPresetsPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return presets, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &presets); err != nil {
+		return nil, fmt.Errorf("invalid window presets file: %w", err)
+	}
+
+	return presets, nil
+}
+
+func writeWindowPresets(presets map[string]WindowPreset) error {
+	content, err := json.MarshalIndent(presets, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(windowPresetsPath()), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(windowPresetsPath(), content, 0644)
+}
+
+func NewCmdWindowPresetSave() *cobra.Command {
+	return &cobra.Command{
+		Use:  "save <name>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			window, err := client.GetFocusedWindow(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			preset := WindowPreset{State: window.State}
+			if window.State == "normal" {
+				preset.Left = window.Left
+				preset.Top = window.Top
+				preset.Width = window.Width
+				preset.Height = window.Height
+			}
+
+			presets, err := loadWindowPresets()
+			if err != nil {
+				return err
+			}
+
+			presets[args[0]] = preset
+			if err := writeWindowPresets(presets); err != nil {
+				return fmt.Errorf("unable to save preset: %w", err)
+			}
+
+			cmd.Printf("Saved preset %s: %s\n", args[0], preset)
+			return nil
+		},
+	}
+}
+
+func NewCmdWindowPresetApply() *cobra.Command {
+	return &cobra.Command{
+		Use:  "apply <name> [windowId]",
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			presets, err := loadWindowPresets()
+			if err != nil {
+				return err
+			}
+
+			preset, ok := presets[args[0]]
+			if !ok {
+				return fmt.Errorf("no window preset named %s", args[0])
+			}
+
+			var windowId int
+			if len(args) > 1 {
+				windowId, err = strconv.Atoi(args[1])
+				if err != nil {
+					return fmt.Errorf("invalid window id: %w", err)
+				}
+			} else {
+				window, err := client.GetFocusedWindow(cmd.Context())
+				if err != nil {
+					return err
+				}
+				windowId = window.ID
+			}
+
+			msg := map[string]any{
+				"command":  "window.update",
+				"windowId": windowId,
+				"state":    preset.State,
+			}
+
+			// the browser rejects dimensions for maximized, minimized or fullscreen windows
+			if preset.State == "normal" {
+				msg["left"] = preset.Left
+				msg["top"] = preset.Top
+				msg["width"] = preset.Width
+				msg["height"] = preset.Height
+			}
+
+			if _, err := sendMessage(msg); err != nil {
+				return err
+			}
+
+			cmd.Printf("Applied preset %s to window %d\n", args[0], windowId)
+			return nil
+		},
+	}
+}
+
+func NewCmdWindowPresetList(printer tableprinter.TablePrinter) *cobra.Command {
+	return &cobra.Command{
+		Use:  "list",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			presets, err := loadWindowPresets()
+			if err != nil {
+				return err
+			}
+
+			names := make([]string, 0, len(presets))
+			for name := range presets {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				printer.AddField(name)
+				printer.AddField(presets[name].String())
+				printer.EndRow()
+			}
+
+			if err := printer.Render(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
+
+func NewCmdWindowPreset(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "preset",
+	}
+
+	cmd.AddCommand(NewCmdWindowPresetSave())
+	cmd.AddCommand(NewCmdWindowPresetApply())
+	cmd.AddCommand(NewCmdWindowPresetList(printer))
+
+	return cmd
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// the range of print scales accepted by the devtools protocol
+const (
+	minPrintScale = 0.1
+	maxPrintScale = 2.0
+)
+
+func NewCmdTabPrint() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "print [tabId]",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeTabID,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString("output")
+			if output == "" && isatty.IsTerminal(os.Stdout.Fd()) {
+				return fmt.Errorf("refusing to write a pdf to the terminal, use --output or redirect stdout")
+			}
+
+			scale, _ := cmd.Flags().GetFloat64("scale")
+			if scale < minPrintScale || scale > maxPrintScale {
+				return fmt.Errorf("invalid scale: %g, expected a factor between %g and %g", scale, minPrintScale, maxPrintScale)
+			}
+
+			landscape, _ := cmd.Flags().GetBool("landscape")
+			background, _ := cmd.Flags().GetBool("background")
+			msg := map[string]any{
+				"command":         "tab.printToPDF",
+				"landscape":       landscape,
+				"printBackground": background,
+				"scale":           scale,
+			}
+
+			if len(args) > 0 {
+				tabId, err := resolveSingleTabID(args[0])
+				if err != nil {
+					return err
+				}
+
+				msg["tabId"] = tabId
+			}
+
+			res, err := sendMessage(msg)
+			if err != nil {
+				return err
+			}
+
+			var encoded string
+			if err := decodeResponse(res, &encoded); err != nil {
+				return err
+			}
+
+			pdf, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return fmt.Errorf("invalid pdf data: %w", err)
+			}
+
+			if output == "" {
+				_, err := os.Stdout.Write(pdf)
+				return err
+			}
+
+			if err := os.WriteFile(output, pdf, 0644); err != nil {
+				return fmt.Errorf("unable to write pdf: %w", err)
+			}
+
+			cmd.Printf("PDF written to %s\n", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "file to write the pdf to, defaults to stdout")
+	cmd.Flags().Bool("landscape", false, "use the landscape orientation")
+	cmd.Flags().Bool("background", false, "print the background graphics")
+	cmd.Flags().Float64("scale", 1, "scale of the page rendering, between 0.1 and 2")
+
+	return cmd
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/pomdtr/webterm/webterm"
+)
+
+var protocolLog struct {
+	sync.Mutex
+	encoder *json.Encoder
+}
+
+// openProtocolLog starts writing protocol events to the given file, truncating it unless appending.
+func openProtocolLog(path string, appendLog bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if appendLog {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+
+	f, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open log file: %w", err)
+	}
+
+	protocolLog.Lock()
+	defer protocolLog.Unlock()
+	protocolLog.encoder = json.NewEncoder(f)
+
+	return nil
+}
+
+// logProtocol writes an event to the log file of the cli, and to the protocol log if one is open.
+func logProtocol(event webterm.Event) {
+	switch event.Event {
+	case "send":
+		log.Printf("Sending request %s: %s", event.RequestID, event.Payload)
+	case "receive":
+		log.Printf("Received response for request %s (%d bytes)", event.RequestID, event.Bytes)
+	case "error":
+		log.Printf("Received error for request %s: %s", event.RequestID, event.Error)
+	}
+
+	protocolLog.Lock()
+	defer protocolLog.Unlock()
+
+	if protocolLog.encoder == nil {
+		return
+	}
+
+	protocolLog.encoder.Encode(event)
+}
+
+// printTrace prints a protocol event for --verbose, payloads longer than maxBytes are truncated.
+func printTrace(w io.Writer, event webterm.Event, maxBytes int) {
+	truncate := func(b []byte) string {
+		if maxBytes <= 0 || len(b) <= maxBytes {
+			return string(b)
+		}
+		return fmt.Sprintf("%s... (%d more bytes)", b[:maxBytes], len(b)-maxBytes)
+	}
+
+	switch event.Event {
+	case "send":
+		fmt.Fprintf(w, "> %s\n", truncate(event.Payload))
+	case "receive":
+		fmt.Fprintf(w, "< %s (%.1fms)\n", truncate(event.Body), event.Duration)
+	case "error":
+		fmt.Fprintf(w, "< error: %s (%.1fms)\n", truncate([]byte(event.Error)), event.Duration)
+	}
+}
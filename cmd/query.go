@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/pomdtr/webterm/webterm"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdTabQuery(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "query",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var query webterm.TabQuery
+			for name, field := range map[string]**bool{
+				"active":  &query.Active,
+				"pinned":  &query.Pinned,
+				"audible": &query.Audible,
+				"muted":   &query.Muted,
+			} {
+				// the properties are only sent when set, --active=false matches the inactive tabs
+				if cmd.Flags().Changed(name) {
+					value, _ := cmd.Flags().GetBool(name)
+					*field = &value
+				}
+			}
+
+			query.URL, _ = cmd.Flags().GetStringArray("url")
+			query.WindowID, _ = cmd.Flags().GetInt("window")
+			query.CurrentWindow, _ = cmd.Flags().GetBool("current-window")
+
+			query.Status, _ = cmd.Flags().GetString("status")
+			switch query.Status {
+			case "", "loading", "complete", "unloaded":
+			default:
+				return fmt.Errorf("invalid --status value: %s, expected loading, complete or unloaded", query.Status)
+			}
+
+			tabs, err := client.QueryTabs(cmd.Context(), query)
+			if err != nil {
+				return err
+			}
+
+			return writeTabs(cmd, printer, tabs)
+		},
+	}
+
+	cmd.Flags().Bool("active", false, "only match the active tabs, --active=false for the others")
+	cmd.Flags().Bool("pinned", false, "only match the pinned tabs, --pinned=false for the others")
+	cmd.Flags().Bool("audible", false, "only match the tabs playing sound, --audible=false for the others")
+	cmd.Flags().Bool("muted", false, "only match the muted tabs, --muted=false for the others")
+	cmd.Flags().StringArray("url", nil, "only match the tabs whose url matches the pattern, e.g. *://*.github.com/* (repeatable)")
+	cmd.Flags().Int("window", 0, "only match the tabs of the window with the given id")
+	cmd.Flags().Bool("current-window", false, "only match the tabs of the current window")
+	cmd.MarkFlagsMutuallyExclusive("window", "current-window")
+	cmd.Flags().String("status", "", "only match the tabs with the given status: loading, complete or unloaded")
+	cmd.Flags().String("format", "table", "output format: table, json, csv or tsv")
+	addHeaderFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/pomdtr/webterm/webterm"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdTabRestore(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "restore [sessionId]",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var sessionId string
+			if len(args) > 0 {
+				sessionId = args[0]
+			}
+
+			session, err := client.RestoreSession(cmd.Context(), sessionId)
+			if err != nil {
+				return err
+			}
+
+			if session.Window != nil {
+				format, err := tabOutputFormat(cmd)
+				if err != nil {
+					return err
+				}
+
+				if format == "json" {
+					return writeJSON(cmd, session.Window)
+				}
+
+				cmd.Printf("Restored window %d\n", session.Window.ID)
+				return nil
+			}
+
+			if session.Tab == nil {
+				return fmt.Errorf("no recently closed tab to restore")
+			}
+
+			return writeTab(cmd, printer, *session.Tab)
+		},
+	}
+
+	cmd.Flags().String("format", "table", "output format: table, json, csv or tsv")
+
+	return cmd
+}
+
+func NewCmdTabRecentlyClosed(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "recently-closed",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			limit, _ := cmd.Flags().GetInt("limit")
+			if limit < 0 {
+				return fmt.Errorf("--limit must be positive")
+			}
+
+			sessions, err := client.RecentlyClosed(cmd.Context(), limit)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+				if sessions == nil {
+					sessions = []webterm.Session{}
+				}
+				return writeJSON(cmd, sessions)
+			}
+
+			for _, session := range sessions {
+				printer.AddField(session.SessionID())
+				// lastModified is in seconds, unlike the other browser timestamps
+				printer.AddField(time.Unix(int64(session.LastModified), 0).Format(time.RFC3339))
+				switch {
+				case session.Tab != nil:
+					printer.AddField("tab")
+					printer.AddField(session.Tab.Title)
+					printer.AddField(session.Tab.URL)
+				case session.Window != nil:
+					printer.AddField("window")
+					printer.AddField(pluralize(len(session.Window.Tabs), "tab"))
+					printer.AddField("")
+				}
+				printer.EndRow()
+			}
+
+			return printer.Render()
+		},
+	}
+
+	cmd.Flags().Int("limit", 0, "maximum number of entries to show, 0 for the browser default of 25")
+
+	return cmd
+}
@@ -1,25 +1,22 @@
 package cmd
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	_ "embed"
 
-	"github.com/adrg/xdg"
 	"github.com/cli/go-gh/v2/pkg/tableprinter"
 	"github.com/mattn/go-isatty"
+	"github.com/pomdtr/webterm/webterm"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
 
-const webtermPort = 9999
-
 var (
 	//go:embed manifest.json
 	manifest []byte
@@ -27,46 +24,55 @@ var (
 	entrypoint []byte
 )
 
-func sendMessage(payload any) ([]byte, error) {
-	target := fmt.Sprintf("http://localhost:%d/browser", webtermPort)
-	b, err := json.Marshal(payload)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := http.Post(target, "application/json", bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
+// client sends the commands to the browser, its timeout is set from the command being run.
+var client = webterm.NewClient()
 
-	if res.StatusCode != http.StatusOK {
-		msg, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf(string(msg))
-	}
+// execCtx is canceled when the user interrupts the running command.
+var execCtx = context.Background()
 
-	return io.ReadAll(res.Body)
+// sendMessage sends a command with the client, setting client.Transport runs the commands without a browser.
+func sendMessage(payload any) ([]byte, error) {
+	return client.Send(execCtx, payload)
 }
 
 func NewCmdInit() *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "init",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			manifestPath := filepath.Join(xdg.DataHome, "Google", "Chrome", "NativeMessagingHosts", "com.pomdtr.webterm.json")
-			cmd.Printf("Writing manifest file to %s\n", manifestPath)
-			if err := os.WriteFile(manifestPath, []byte(manifest), 0644); err != nil {
-				return fmt.Errorf("unable to write manifest file: %w", err)
+			browser, err := resolveBrowser(cmd)
+			if err != nil {
+				return err
 			}
-			cmd.Printf("Manifest file written successfully\n")
 
 			homeDir, err := os.UserHomeDir()
 			if err != nil {
 				return fmt.Errorf("unable to get user home directory: %w", err)
 			}
 
-			entrypointPath := filepath.Join(homeDir, ".local", "bin", "webterm.sh")
+			// chrome keeps the original name of the entrypoint
+			entrypointName := "webterm.sh"
+			if browser != webterm.DefaultBrowser {
+				entrypointName = fmt.Sprintf("webterm-%s.sh", browser)
+			}
+
+			entrypointPath := filepath.Join(homeDir, ".local", "bin", entrypointName)
+			content, err := browserManifest(browser, entrypointPath)
+			if err != nil {
+				return err
+			}
+
+			manifestPath := filepath.Join(nativeHostDirs[browser], "com.pomdtr.webterm.json")
+			cmd.Printf("Writing manifest file to %s\n", manifestPath)
+			if err := os.MkdirAll(filepath.Dir(manifestPath), 0755); err != nil {
+				return fmt.Errorf("unable to create manifest directory: %w", err)
+			}
+			if err := os.WriteFile(manifestPath, content, 0644); err != nil {
+				return fmt.Errorf("unable to write manifest file: %w", err)
+			}
+			cmd.Printf("Manifest file written successfully\n")
+
 			cmd.Printf("Writing entrypoint file to %s\n", entrypointPath)
-			if err := os.WriteFile(entrypointPath, []byte(entrypoint), 0755); err != nil {
+			if err := os.WriteFile(entrypointPath, browserEntrypoint(browser), 0755); err != nil {
 				return fmt.Errorf("unable to write entrypoint file: %w", err)
 			}
 			cmd.Printf("Entrypoint file written successfully\n")
@@ -78,33 +84,159 @@ func NewCmdInit() *cobra.Command {
 	return cmd
 }
 
-func Execute() error {
-	cmd := &cobra.Command{
-		Use:          "webterm",
-		SilenceUsage: true,
-	}
+// newPrinter returns a table printer sized for stdout.
+func newPrinter() (tableprinter.TablePrinter, error) {
 	var isTTY bool
 	var width int
 	if isatty.IsTerminal(os.Stdout.Fd()) {
 		isTTY = true
 		w, _, err := term.GetSize(int(os.Stdout.Fd()))
 		if err != nil {
-			return err
+			return nil, err
 		}
 		width = w
 	}
 
-	printer := tableprinter.New(os.Stdout, isTTY, width)
+	return tableprinter.New(os.Stdout, isTTY, width), nil
+}
+
+// isCompletionCommand reports whether cmd is the completion command generating the shell scripts, or one of its subcommands.
+func isCompletionCommand(cmd *cobra.Command) bool {
+	for ; cmd.HasParent(); cmd = cmd.Parent() {
+		if cmd.Name() == "completion" && !cmd.Parent().HasParent() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveTimeout picks the timeout of a command: the --timeout flag when set, then the timeout of the command in the config,
+// then the default timeout of the config, then the built-in default.
+func resolveTimeout(cmd *cobra.Command) (time.Duration, error) {
+	if cmd.Flags().Changed("timeout") {
+		return cmd.Flags().GetDuration("timeout")
+	}
+
+	if timeout, ok, err := config.commandTimeout(cmd); err != nil || ok {
+		return timeout, err
+	}
+
+	if timeout, ok, err := config.defaultTimeout(); err != nil || ok {
+		return timeout, err
+	}
+
+	return cmd.Flags().GetDuration("timeout")
+}
+
+func Execute() error {
+	printer, err := newPrinter()
+	if err != nil {
+		return err
+	}
+
+	cmd := NewCmdRoot(printer)
+
+	ctx, stop := notifyContext(context.Background())
+	defer stop()
+	execCtx = ctx
+
+	return cmd.ExecuteContext(ctx)
+}
+
+// NewCmdRoot returns the webterm command, printing its tables with printer.
+func NewCmdRoot(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "webterm",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			silenceStatus(cmd)
+
+			// the completion scripts are generated offline, a broken config or browser must not prevent installing them
+			if isCompletionCommand(cmd) {
+				return nil
+			}
+
+			loaded, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			config = loaded
+
+			timeout, err := resolveTimeout(cmd)
+			if err != nil {
+				return err
+			}
+
+			browser, err := resolveBrowser(cmd)
+			if err != nil {
+				return err
+			}
+
+			port, err := webterm.BrowserPort(browser)
+			if err != nil {
+				return err
+			}
+
+			client.Port = port
+			client.HTTPClient.Timeout = timeout
+			client.Retries, _ = cmd.Flags().GetInt("retries")
+			client.Trace = logProtocol
+			if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
+				webterm.DecodeErrorExcerpt = 0
+				maxBytes, _ := cmd.Flags().GetInt("verbose-max-bytes")
+				client.Trace = func(event webterm.Event) {
+					logProtocol(event)
+					printTrace(cmd.ErrOrStderr(), event, maxBytes)
+				}
+				client.OnRetry = func(attempt int, delay time.Duration, err error) {
+					cmd.PrintErrf("Request failed (%v), retrying in %s (%d/%d)\n", err, delay, attempt, client.Retries)
+				}
+			}
+
+			if logFile, _ := cmd.Flags().GetString("log-file"); logFile != "" {
+				appendLog, _ := cmd.Flags().GetBool("log-append")
+				if err := openProtocolLog(logFile, appendLog); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().String("browser", webterm.DefaultBrowser, fmt.Sprintf("browser to send the commands to: %s, defaults to $%s", strings.Join(webterm.BrowserNames(), ", "), browserEnv))
+	cmd.PersistentFlags().BoolP("quiet", "q", false, "only print the requested values and the errors, no summaries or confirmations")
+	cmd.PersistentFlags().Bool("compact", false, "output json on a single line, the default when stdout is not a terminal")
+	cmd.PersistentFlags().Bool("no-color", false, "do not colorize the tables, also disabled by the NO_COLOR environment variable")
+	cmd.PersistentFlags().Bool("pretty", false, "output indented json, even when stdout is not a terminal")
+	cmd.MarkFlagsMutuallyExclusive("compact", "pretty")
+	cmd.PersistentFlags().Bool("sort-keys", false, "sort json object keys for deterministic output")
+	cmd.PersistentFlags().String("log-file", "", "write the requests sent to the browser as json lines to this file")
+	cmd.PersistentFlags().Bool("log-append", false, "append to the --log-file instead of truncating it")
+	cmd.PersistentFlags().Duration("timeout", webterm.DefaultTimeout, "how long to wait for the browser, 0 to wait forever")
+	cmd.PersistentFlags().Int("retries", webterm.DefaultRetries, "how many times to retry a request failing with a transient error, actions changing the browser are only retried when they were not sent")
+	cmd.PersistentFlags().BoolP("verbose", "v", false, "print the requests sent to the browser and their responses to stderr")
+	cmd.PersistentFlags().Int("verbose-max-bytes", 1024, "truncate the payloads printed by --verbose to this many bytes, 0 for no limit")
 
 	cmd.AddCommand(NewCmdInit())
 	cmd.AddCommand(NewCmdServer())
+	cmd.AddCommand(NewCmdDoctor())
+	cmd.AddCommand(NewCmdVersion())
+	cmd.AddCommand(NewCmdConfig())
+	cmd.AddCommand(NewCmdBatch())
 	cmd.AddCommand(NewCmdTab(printer))
 	cmd.AddCommand(NewCmdWindow(printer))
-	cmd.AddCommand(NewCmdHistory())
+	cmd.AddCommand(NewCmdHistory(printer))
 	cmd.AddCommand(NewCmdExtension(printer))
-	cmd.AddCommand(NewCmdBookMark())
+	cmd.AddCommand(NewCmdBookMark(printer))
+	cmd.AddCommand(NewCmdCookie(printer))
 	cmd.AddCommand(NewCmdDownload(printer))
 	cmd.AddCommand(NewCmdSelection())
+	cmd.AddCommand(NewCmdContainer(printer))
+	cmd.AddCommand(NewCmdAudio(printer))
 
-	return cmd.Execute()
+	markUsageErrors(cmd)
+
+	return cmd
 }
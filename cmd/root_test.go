@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/creack/pty"
+)
+
+// fakeTransport answers the commands with canned json responses and records the payloads sent.
+type fakeTransport struct {
+	// responses maps a command to its json response, commands without one answer null.
+	responses map[string]string
+	// errors maps a command to the error it fails with.
+	errors map[string]error
+	sent   []map[string]any
+}
+
+func (f *fakeTransport) Send(ctx context.Context, payload any) ([]byte, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg map[string]any
+	if err := json.Unmarshal(b, &msg); err != nil {
+		return nil, err
+	}
+	f.sent = append(f.sent, msg)
+
+	command, _ := msg["command"].(string)
+	if err, ok := f.errors[command]; ok {
+		return nil, err
+	}
+	if res, ok := f.responses[command]; ok {
+		return []byte(res), nil
+	}
+
+	return []byte("null"), nil
+}
+
+// commands returns the names of the commands sent, in order.
+func (f *fakeTransport) commands() []string {
+	commands := make([]string, len(f.sent))
+	for i, msg := range f.sent {
+		commands[i], _ = msg["command"].(string)
+	}
+	return commands
+}
+
+// assertSent checks that the payloads sent match the given json objects, in order.
+func (f *fakeTransport) assertSent(t *testing.T, want ...string) {
+	t.Helper()
+
+	if len(f.sent) != len(want) {
+		t.Fatalf("sent %d commands %v, want %d", len(f.sent), f.commands(), len(want))
+	}
+
+	for i, raw := range want {
+		var msg map[string]any
+		if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+			t.Fatalf("invalid expected payload %s: %v", raw, err)
+		}
+
+		if !reflect.DeepEqual(f.sent[i], msg) {
+			got, _ := json.Marshal(f.sent[i])
+			t.Errorf("payload %d = %s, want %s", i, got, raw)
+		}
+	}
+}
+
+// isolateConfig points the config and state directories to an empty temporary directory.
+func isolateConfig(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("XDG_STATE_HOME", dir)
+	t.Setenv("WEBTERM_BROWSER", "")
+	t.Setenv("WEBTERM_SEARCH_ENGINE", "")
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	return dir
+}
+
+// runCommand runs webterm with the given arguments through the transport, and returns what it printed on stdout and stderr.
+func runCommand(t *testing.T, transport *fakeTransport, args ...string) (string, string, error) {
+	t.Helper()
+
+	client.Transport = transport
+	t.Cleanup(func() {
+		client.Transport = nil
+	})
+
+	stdout, stdoutWriter := capture(t, &os.Stdout)
+	stderr, _ := capture(t, &os.Stderr)
+
+	root := NewCmdRoot(tableprinter.New(stdoutWriter, false, 0))
+	root.SetArgs(args)
+	err := root.ExecuteContext(context.Background())
+
+	return stdout(), stderr(), err
+}
+
+// runCommandOnTerminal runs webterm like runCommand, with its stdout attached to a terminal.
+// It returns what was printed on stderr.
+func runCommandOnTerminal(t *testing.T, transport *fakeTransport, args ...string) (string, error) {
+	t.Helper()
+
+	client.Transport = transport
+	t.Cleanup(func() {
+		client.Transport = nil
+	})
+
+	ptmx, tty, err := pty.Open()
+	if err != nil {
+		t.Skipf("no terminal available: %v", err)
+	}
+	defer ptmx.Close()
+	defer tty.Close()
+	go io.Copy(io.Discard, ptmx)
+
+	stdout := os.Stdout
+	os.Stdout = tty
+	defer func() {
+		os.Stdout = stdout
+	}()
+	stderr, _ := capture(t, &os.Stderr)
+
+	root := NewCmdRoot(tableprinter.New(tty, true, 80))
+	root.SetArgs(args)
+	err = root.ExecuteContext(context.Background())
+
+	return stderr(), err
+}
+
+// capture replaces the file f with a pipe, until the returned function is called which restores it
+// and returns what was written to it. The writing end of the pipe is also returned.
+func capture(t *testing.T, f **os.File) (func() string, io.Writer) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orig := *f
+	*f = w
+
+	var out bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		io.Copy(&out, r)
+		close(done)
+	}()
+
+	restored := false
+	restore := func() {
+		if restored {
+			return
+		}
+		restored = true
+		*f = orig
+		w.Close()
+		<-done
+	}
+	t.Cleanup(restore)
+
+	return func() string {
+		restore()
+		return out.String()
+	}, w
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// maxScale is the highest capture scale accepted by tab screenshot.
+const maxScale = 4
+
+// screenshotWarnSize is the estimated capture size past which tab screenshot warns, large
+// responses have to go through native messaging in one piece.
+const screenshotWarnSize = 32 << 20
+
+func parseScale(value float64) (float64, error) {
+	if value <= 0 || value > maxScale {
+		return 0, fmt.Errorf("invalid scale: %g, expected a factor greater than 0 and at most %d", value, maxScale)
+	}
+
+	return value, nil
+}
+
+// estimateCaptureSize is the worst case size of the base64 encoded png of a scaled viewport.
+func estimateCaptureSize(width int, height int, scale float64) int {
+	pixels := float64(width) * scale * float64(height) * scale
+	return int(pixels * 4 * 4 / 3)
+}
+
+func NewCmdTabScreenshot() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "screenshot [tabId]",
+		Aliases:           []string{"capture"},
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeTabID,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			if format != "png" && format != "jpeg" {
+				return fmt.Errorf("invalid format: %s, expected png or jpeg", format)
+			}
+
+			output, _ := cmd.Flags().GetString("output")
+			if output == "" && isatty.IsTerminal(os.Stdout.Fd()) {
+				return fmt.Errorf("refusing to write a %s to the terminal, use --output or redirect stdout", format)
+			}
+
+			msg := map[string]any{
+				"command": "tab.screenshot",
+				"format":  format,
+			}
+
+			if cmd.Flags().Changed("quality") {
+				if format != "jpeg" {
+					return fmt.Errorf("--quality only applies to the jpeg format")
+				}
+
+				quality, _ := cmd.Flags().GetInt("quality")
+				if quality < 0 || quality > 100 {
+					return fmt.Errorf("invalid quality: %d, expected a value between 0 and 100", quality)
+				}
+				msg["quality"] = quality
+			}
+
+			if len(args) > 0 {
+				tabId, err := resolveSingleTabID(args[0])
+				if err != nil {
+					return err
+				}
+
+				msg["tabId"] = tabId
+			}
+
+			flag := "scale"
+			if cmd.Flags().Changed("dpr") {
+				flag = "dpr"
+			}
+
+			if cmd.Flags().Changed(flag) {
+				value, _ := cmd.Flags().GetFloat64(flag)
+				scale, err := parseScale(value)
+				if err != nil {
+					return err
+				}
+				msg["scale"] = scale
+
+				tab, err := getTabArg(cmd, args)
+				if err != nil {
+					return err
+				}
+
+				if size := estimateCaptureSize(tab.Width, tab.Height, scale); size > screenshotWarnSize {
+					cmd.PrintErrf("Warning: a %gx capture of a %dx%d viewport may reach %s, which can exceed the native messaging limit\n", scale, tab.Width, tab.Height, humanizeBytes(size))
+				}
+			}
+
+			res, err := sendMessage(msg)
+			if err != nil {
+				return err
+			}
+
+			var encoded string
+			if err := decodeResponse(res, &encoded); err != nil {
+				return err
+			}
+
+			image, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return fmt.Errorf("invalid screenshot data: %w", err)
+			}
+
+			if output == "" {
+				_, err := os.Stdout.Write(image)
+				return err
+			}
+
+			if err := os.WriteFile(output, image, 0644); err != nil {
+				return fmt.Errorf("unable to write screenshot: %w", err)
+			}
+
+			cmd.Printf("Screenshot written to %s\n", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "file to write the image to, defaults to stdout")
+	cmd.Flags().String("format", "png", "image format, png or jpeg")
+	cmd.Flags().Int("quality", 92, "jpeg quality, between 0 and 100")
+	cmd.Flags().Float64("scale", 1, "capture scale, e.g. 2 for a retina screenshot")
+	cmd.Flags().Float64("dpr", 1, "device pixel ratio of the capture, same as --scale")
+	cmd.MarkFlagsMutuallyExclusive("scale", "dpr")
+
+	return cmd
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// restrictedPageErrors are the errors the browser raises when a page can not be scripted at all.
+var restrictedPageErrors = []string{
+	"Cannot access contents of",
+	"Cannot access a chrome",
+	"Cannot access a chrome-extension",
+	"cannot be scripted",
+	"Missing host permission",
+	"The extensions gallery cannot be scripted",
+}
+
+// cspErrors are the errors raised when the Content-Security-Policy of a page blocks the script.
+var cspErrors = []string{
+	"Content Security Policy",
+	"Content-Security-Policy",
+	"unsafe-eval",
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// explainScriptingError turns the errors of commands running a script in the page into a
+// helpful message. The fallback flag is suggested when the script was blocked by the page,
+// restricted pages can not be read at all.
+func explainScriptingError(err error, fallback string) error {
+	if err == nil {
+		return nil
+	}
+
+	msg := err.Error()
+	if containsAny(msg, restrictedPageErrors) {
+		return fmt.Errorf("the page can not be scripted, browser pages and the extension store are restricted (%s)", msg)
+	}
+
+	if !containsAny(msg, cspErrors) {
+		return err
+	}
+
+	if fallback != "" {
+		return fmt.Errorf("the Content-Security-Policy of the page blocked the script, retry with %s (%s)", fallback, msg)
+	}
+
+	return fmt.Errorf("the Content-Security-Policy of the page blocked the script (%s)", msg)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdTabScroll() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "scroll",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg := map[string]any{
+				"command": "tab.scroll",
+			}
+
+			switch {
+			case cmd.Flags().Changed("to"):
+				to, _ := cmd.Flags().GetString("to")
+				if to != "top" && to != "bottom" {
+					return fmt.Errorf("invalid scroll position: %s, expected top or bottom", to)
+				}
+				msg["to"] = to
+			case cmd.Flags().Changed("by"):
+				by, _ := cmd.Flags().GetInt("by")
+				msg["by"] = by
+			case cmd.Flags().Changed("to-selector"):
+				selector, _ := cmd.Flags().GetString("to-selector")
+				msg["selector"] = selector
+			default:
+				return fmt.Errorf("one of --to, --by or --to-selector is required")
+			}
+
+			if cmd.Flags().Changed("tab") {
+				tabId, _ := cmd.Flags().GetInt("tab")
+				msg["tabId"] = tabId
+			}
+
+			if _, err := sendMessage(msg); err != nil {
+				return explainScriptingError(err, "")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int("tab", 0, "id of the tab to scroll, defaults to the active tab")
+	cmd.Flags().String("to", "", "scroll to the top or bottom of the page")
+	cmd.Flags().Int("by", 0, "scroll down by this many pixels, negative values scroll up")
+	cmd.Flags().String("to-selector", "", "scroll the first element matching the css selector into view")
+	cmd.MarkFlagsMutuallyExclusive("to", "by", "to-selector")
+
+	return cmd
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// addSelectFlags registers the flags selecting and ordering tabs, see selectTabs.
+func addSelectFlags(cmd *cobra.Command) {
+	addFilterFlags(cmd)
+	cmd.Flags().String("sort", "", "sort the tabs by id, index, title, url, window or lastAccessed")
+	cmd.Flags().Bool("reverse", false, "reverse the order of the tabs")
+}
+
+// addFilterFlags registers the flags selecting tabs, without the ones ordering them.
+func addFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().String("filter", "", "only show tabs whose url or title contain the given text")
+	cmd.Flags().Bool("regex", false, "treat --filter as a regular expression")
+	cmd.Flags().Bool("case-sensitive", false, "match --filter case-sensitively")
+	cmd.Flags().Bool("active", false, "only show active tabs")
+	cmd.Flags().Bool("pinned", false, "only show pinned tabs")
+	cmd.Flags().Bool("audible", false, "only show tabs playing sound")
+	cmd.Flags().Bool("muted", false, "only show muted tabs")
+	cmd.Flags().Int("group", 0, "only show tabs in the group with the given id, -1 for ungrouped tabs")
+	cmd.Flags().StringArray("exclude", nil, "exclude tabs whose url or title match the regex (repeatable)")
+	addStatusFlag(cmd)
+}
+
+// addStatusFlag registers the --status flag read by filterTabsByStatus.
+func addStatusFlag(cmd *cobra.Command) {
+	cmd.Flags().String("status", "", "only use the tabs with the given status: loading, complete, unloaded or crashed")
+}
+
+// filterTabsByStatus keeps the tabs with the --status of the command. The browser does not report crashed tabs,
+// loaded tabs which can not be scripted because of a crash or an error page are probed one by one.
+func filterTabsByStatus(cmd *cobra.Command, tabs []Tab) ([]Tab, error) {
+	status, _ := cmd.Flags().GetString("status")
+	switch status {
+	case "":
+		return tabs, nil
+	case "loading", "complete", "unloaded":
+		var kept []Tab
+		for _, tab := range tabs {
+			if tab.Status == status {
+				kept = append(kept, tab)
+			}
+		}
+		return kept, nil
+	case "crashed":
+		var kept []Tab
+		for _, tab := range tabs {
+			if tab.Status != "complete" || tab.Discarded {
+				continue
+			}
+
+			crashed, err := isErrorTab(cmd.Context(), tab, nil)
+			if err != nil {
+				return nil, err
+			}
+			if crashed {
+				kept = append(kept, tab)
+			}
+		}
+		return kept, nil
+	default:
+		return nil, fmt.Errorf("invalid --status value: %s, expected loading, complete, unloaded or crashed", status)
+	}
+}
+
+// addWindowFlags registers the flags picking the window to list the tabs of, see listWindowTabs.
+func addWindowFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("window", 0, "only use the tabs of the window with the given id, instead of the current window")
+	cmd.Flags().Bool("current", false, "only use the tabs of the focused window, instead of the current window")
+	cmd.Flags().Bool("all-windows", false, "use the tabs of every window, instead of the current window")
+	cmd.MarkFlagsMutuallyExclusive("window", "current", "all-windows")
+}
+
+// listWindowTabs lists the tabs of the window picked by the flags registered by addWindowFlags,
+// the current window by default.
+func listWindowTabs(cmd *cobra.Command) ([]Tab, error) {
+	if allWindows, _ := cmd.Flags().GetBool("all-windows"); allWindows {
+		return client.ListAllTabs(cmd.Context())
+	}
+
+	if current, _ := cmd.Flags().GetBool("current"); current {
+		window, err := client.GetFocusedWindow(cmd.Context())
+		if err != nil {
+			return nil, err
+		}
+		return client.ListWindowTabs(cmd.Context(), window.ID)
+	}
+
+	if cmd.Flags().Changed("window") {
+		windowId, _ := cmd.Flags().GetInt("window")
+		return client.ListWindowTabs(cmd.Context(), windowId)
+	}
+
+	return client.ListTabs(cmd.Context())
+}
+
+// tabSorts are the orders accepted by --sort.
+var tabSorts = map[string]func(a, b Tab) bool{
+	"id":    func(a, b Tab) bool { return a.ID < b.ID },
+	"title": func(a, b Tab) bool { return a.Title < b.Title },
+	"url":   func(a, b Tab) bool { return a.URL < b.URL },
+	// indexes are per window, so tabs are ordered as they appear in each window
+	"index": func(a, b Tab) bool {
+		if a.WindowID != b.WindowID {
+			return a.WindowID < b.WindowID
+		}
+		return a.Index < b.Index
+	},
+	"window": func(a, b Tab) bool { return a.WindowID < b.WindowID },
+	// most recently accessed first, tabs without an access time last
+	"lastAccessed": func(a, b Tab) bool { return a.LastAccessed > b.LastAccessed },
+}
+
+// selectTabs keeps the tabs matching the flags registered by addSelectFlags, in the requested order.
+func selectTabs(cmd *cobra.Command, tabs []Tab) ([]Tab, error) {
+	if filter, _ := cmd.Flags().GetString("filter"); filter != "" {
+		pattern := filter
+		if useRegex, _ := cmd.Flags().GetBool("regex"); !useRegex {
+			pattern = regexp.QuoteMeta(filter)
+		}
+		if caseSensitive, _ := cmd.Flags().GetBool("case-sensitive"); !caseSensitive {
+			pattern = "(?i)" + pattern
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter pattern: %w", err)
+		}
+
+		var kept []Tab
+		for _, tab := range tabs {
+			if re.MatchString(tab.URL) || re.MatchString(tab.Title) {
+				kept = append(kept, tab)
+			}
+		}
+		tabs = kept
+	}
+
+	states := map[string]func(Tab) bool{
+		"active":  func(t Tab) bool { return t.Active },
+		"pinned":  func(t Tab) bool { return t.Pinned },
+		"audible": func(t Tab) bool { return t.Audible },
+		"muted":   func(t Tab) bool { return t.MutedInfo.Muted },
+	}
+	for name, state := range states {
+		if enabled, _ := cmd.Flags().GetBool(name); !enabled {
+			continue
+		}
+
+		var kept []Tab
+		for _, tab := range tabs {
+			if state(tab) {
+				kept = append(kept, tab)
+			}
+		}
+		tabs = kept
+	}
+
+	if cmd.Flags().Changed("group") {
+		groupId, _ := cmd.Flags().GetInt("group")
+
+		var kept []Tab
+		for _, tab := range tabs {
+			if tab.GroupID == groupId {
+				kept = append(kept, tab)
+			}
+		}
+		tabs = kept
+	}
+
+	excludes, _ := cmd.Flags().GetStringArray("exclude")
+	for _, exclude := range excludes {
+		re, err := regexp.Compile(exclude)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern: %w", err)
+		}
+
+		var kept []Tab
+		for _, tab := range tabs {
+			if re.MatchString(tab.URL) || re.MatchString(tab.Title) {
+				continue
+			}
+			kept = append(kept, tab)
+		}
+		tabs = kept
+	}
+
+	// last, so that only the tabs left are probed for crashes
+	tabs, err := filterTabsByStatus(cmd, tabs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sortTabs(cmd, tabs); err != nil {
+		return nil, err
+	}
+
+	return tabs, nil
+}
+
+// sortTabs orders tabs in place according to --sort and --reverse.
+func sortTabs(cmd *cobra.Command, tabs []Tab) error {
+	if sortBy, _ := cmd.Flags().GetString("sort"); sortBy != "" {
+		less, ok := tabSorts[sortBy]
+		if !ok {
+			return fmt.Errorf("invalid sort: %s, expected id, index, title, url, window or lastAccessed", sortBy)
+		}
+
+		sort.SliceStable(tabs, func(i, j int) bool {
+			return less(tabs[i], tabs[j])
+		})
+	}
+
+	if reverse, _ := cmd.Flags().GetBool("reverse"); reverse {
+		for i, j := 0, len(tabs)-1; i < j; i, j = i+1, j-1 {
+			tabs[i], tabs[j] = tabs[j], tabs[i]
+		}
+	}
+
+	return nil
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"io"
 	"os"
 
@@ -37,7 +36,7 @@ func NewCmdSelection() *cobra.Command {
 			}
 
 			var selection string
-			if err := json.Unmarshal(res, &selection); err != nil {
+			if err := decodeResponse(res, &selection); err != nil {
 				return err
 			}
 
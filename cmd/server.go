@@ -1,13 +1,16 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 
 	"github.com/adrg/xdg"
 	"github.com/joho/godotenv"
 	"github.com/pomdtr/webterm/server"
+	"github.com/pomdtr/webterm/webterm"
 	"github.com/spf13/cobra"
 )
 
@@ -31,11 +34,26 @@ func NewCmdServer() *cobra.Command {
 				}
 			}
 
+			browser, err := resolveBrowser(cmd)
+			if err != nil {
+				return err
+			}
+
+			port, err := webterm.BrowserPort(browser)
+			if err != nil {
+				return err
+			}
+
 			messageHandler := server.NewMessageHandler()
-			server := server.NewServer(messageHandler, environ)
+			server := server.NewServer(messageHandler, environ, port)
 
 			go messageHandler.Loop()
-			if err := server.ListenAndServe(); err != nil {
+			go func() {
+				<-cmd.Context().Done()
+				server.Close()
+			}()
+
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 				return err
 			}
 
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// exit terminates the process, replaced in tests.
+var exit = os.Exit
+
+// notifyContext returns a context canceled on the first SIGINT or SIGTERM, letting the running
+// command stop its requests and exit cleanly. A second signal exits immediately, in case the
+// graceful shutdown hangs on an unresponsive browser.
+func notifyContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+		case <-ctx.Done():
+			return
+		}
+
+		<-signals
+		fmt.Fprintln(os.Stderr, "Interrupted again, exiting")
+		exit(ExitInterrupted)
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel()
+	}
+}
+
+// sleepContext waits for the given duration, returning early with an error when the context is canceled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestNotifyContextSecondSignalExits(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interrupt signals can not be sent on windows")
+	}
+
+	exited := make(chan int, 1)
+	exit = func(code int) {
+		exited <- code
+	}
+	t.Cleanup(func() {
+		exit = os.Exit
+	})
+
+	ctx, stop := notifyContext(context.Background())
+	defer stop()
+
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := process.Signal(os.Interrupt); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the context was not canceled by the first signal")
+	}
+
+	select {
+	case code := <-exited:
+		t.Fatalf("exited with %d on the first signal", code)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err := process.Signal(os.Interrupt); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case code := <-exited:
+		if code != ExitInterrupted {
+			t.Errorf("exited with %d, want %d", code, ExitInterrupted)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the second signal did not exit")
+	}
+}
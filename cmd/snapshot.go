@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Snapshot is the tab set saved by tab snapshot, to be compared later with tab diff.
+type Snapshot struct {
+	Time time.Time     `json:"time"`
+	Tabs []SnapshotTab `json:"tabs"`
+}
+
+// SnapshotTab holds the fields of a tab kept in a snapshot.
+type SnapshotTab struct {
+	ID       int    `json:"id"`
+	WindowID int    `json:"windowId"`
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+}
+
+// SnapshotDiff is the json output of tab diff against a snapshot.
+type SnapshotDiff struct {
+	Added   []SnapshotTab `json:"added"`
+	Removed []SnapshotTab `json:"removed"`
+}
+
+func newSnapshotTabs(tabs []Tab) []SnapshotTab {
+	snapshotTabs := make([]SnapshotTab, len(tabs))
+	for i, tab := range tabs {
+		snapshotTabs[i] = SnapshotTab{
+			ID:       tab.ID,
+			WindowID: tab.WindowID,
+			Title:    tab.Title,
+			URL:      tab.URL,
+		}
+	}
+
+	return snapshotTabs
+}
+
+func readSnapshot(path string) (*Snapshot, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read snapshot: %w", err)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(content, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+
+	return &snapshot, nil
+}
+
+// diffSnapshot lists the tabs opened and closed between two tab sets, matched by url or by id.
+// Urls are compared as multisets, so that closing one of two tabs with the same url is reported.
+func diffSnapshot(before []SnapshotTab, after []SnapshotTab, by string) SnapshotDiff {
+	key := func(tab SnapshotTab) string {
+		if by == "id" {
+			return strconv.Itoa(tab.ID)
+		}
+		return tab.URL
+	}
+
+	remaining := make(map[string]int)
+	for _, tab := range before {
+		remaining[key(tab)]++
+	}
+
+	diff := SnapshotDiff{Added: []SnapshotTab{}, Removed: []SnapshotTab{}}
+	for _, tab := range after {
+		if remaining[key(tab)] > 0 {
+			remaining[key(tab)]--
+			continue
+		}
+		diff.Added = append(diff.Added, tab)
+	}
+
+	for _, tab := range before {
+		if remaining[key(tab)] > 0 {
+			remaining[key(tab)]--
+			diff.Removed = append(diff.Removed, tab)
+		}
+	}
+
+	return diff
+}
+
+// runSnapshotDiff compares the open tabs with a snapshot, printing the opened tabs with a + and the closed ones with a -.
+func runSnapshotDiff(cmd *cobra.Command, path string) error {
+	by, _ := cmd.Flags().GetString("by")
+	if by != "url" && by != "id" {
+		return fmt.Errorf("invalid --by value: %s, expected url or id", by)
+	}
+
+	snapshot, err := readSnapshot(path)
+	if err != nil {
+		return err
+	}
+
+	tabs, err := client.ListAllTabs(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	diff := diffSnapshot(snapshot.Tabs, newSnapshotTabs(tabs), by)
+	if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+		return writeJSON(cmd, diff)
+	}
+
+	for _, tab := range diff.Added {
+		fmt.Printf("+ %s\t%s\n", tab.URL, tab.Title)
+	}
+	for _, tab := range diff.Removed {
+		fmt.Printf("- %s\t%s\n", tab.URL, tab.Title)
+	}
+
+	return nil
+}
+
+func NewCmdTabSnapshot() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "snapshot",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tabs, err := client.ListAllTabs(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			content, err := json.MarshalIndent(Snapshot{
+				Time: time.Now(),
+				Tabs: newSnapshotTabs(tabs),
+			}, "", "  ")
+			if err != nil {
+				return err
+			}
+			content = append(content, '\n')
+
+			output, _ := cmd.Flags().GetString("output")
+			if output == "" {
+				_, err := os.Stdout.Write(content)
+				return err
+			}
+
+			if err := os.WriteFile(output, content, 0644); err != nil {
+				return fmt.Errorf("unable to write snapshot: %w", err)
+			}
+
+			cmd.Printf("Saved %s to %s\n", pluralize(len(tabs), "tab"), output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "file to write the snapshot to, defaults to stdout")
+
+	return cmd
+}
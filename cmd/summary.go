@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+func pluralize(count int, word string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, word)
+	}
+
+	return fmt.Sprintf("%d %ss", count, word)
+}
+
+// isQuiet reports whether --quiet asks to only print the requested values and the errors.
+func isQuiet(cmd *cobra.Command) bool {
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	return quiet
+}
+
+// silenceStatus discards the status messages printed with cmd.Printf when --quiet is set,
+// the values printed to stdout and the errors are left untouched.
+func silenceStatus(cmd *cobra.Command) {
+	if isQuiet(cmd) {
+		cmd.Root().SetOut(io.Discard)
+	}
+}
+
+// affectedCount is the number of tabs changed by a command: the tabs reported by the extension,
+// or the requested ids when it does not report them, no ids meaning the active tab.
+func affectedCount(tabs []Tab, tabIds []int) int {
+	switch {
+	case tabs != nil:
+		return len(tabs)
+	case len(tabIds) > 0:
+		return len(tabIds)
+	default:
+		return 1
+	}
+}
+
+// printSummary reports the outcome of a batch operation on stderr, e.g. "closed 4 tabs (2 skipped)",
+// unless --quiet is set.
+func printSummary(cmd *cobra.Command, verb string, count int, skipped int) {
+	if isQuiet(cmd) {
+		return
+	}
+
+	summary := fmt.Sprintf("%s %s", verb, pluralize(count, "tab"))
+	if skipped > 0 {
+		summary += fmt.Sprintf(" (%d skipped)", skipped)
+	}
+
+	cmd.PrintErrln(summary)
+}
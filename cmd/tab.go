@@ -1,69 +1,255 @@
 package cmd
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/mattn/go-isatty"
+	"github.com/pomdtr/webterm/webterm"
 	"github.com/spf13/cobra"
 )
 
-type Tab struct {
-	Active          bool   `json:"active"`
-	Audible         bool   `json:"audible"`
-	AutoDiscardable bool   `json:"autoDiscardable"`
-	Discarded       bool   `json:"discarded"`
-	FavIconURL      string `json:"favIconUrl"`
-	GroupID         int    `json:"groupId"`
-	Height          int    `json:"height"`
-	Highlighted     bool   `json:"highlighted"`
-	ID              int    `json:"id"`
-	Incognito       bool   `json:"incognito"`
-	Index           int    `json:"index"`
-	MutedInfo       struct {
-		Muted bool `json:"muted"`
-	} `json:"mutedInfo"`
-	Pinned   bool   `json:"pinned"`
-	Selected bool   `json:"selected"`
-	Status   string `json:"status"`
-	Title    string `json:"title"`
-	URL      string `json:"url"`
-	Width    int    `json:"width"`
-	WindowID int    `json:"windowId"`
+type Tab = webterm.Tab
+
+// TabPage is the json output of a paginated tab list.
+type TabPage struct {
+	Total  int         `json:"total"`
+	Offset int         `json:"offset"`
+	Limit  int         `json:"limit"`
+	Tabs   []TabOutput `json:"tabs"`
+}
+
+// paginate returns the tabs in [offset, offset+limit), a zero limit means no limit.
+func paginate(tabs []Tab, offset int, limit int) []Tab {
+	if offset >= len(tabs) {
+		return []Tab{}
+	}
+	tabs = tabs[offset:]
+
+	if limit > 0 && limit < len(tabs) {
+		tabs = tabs[:limit]
+	}
+
+	return tabs
+}
+
+// resolveSingleTabID parses a tab id argument.
+func resolveSingleTabID(arg string) (int, error) {
+	tabId, err := strconv.Atoi(strings.TrimSpace(arg))
+	if err != nil {
+		return 0, fmt.Errorf("invalid tab id: %w", err)
+	}
+
+	return tabId, nil
+}
+
+// resolveTabIDs parses tab ids given as separate or comma-separated arguments, dropping duplicates.
+func resolveTabIDs(args []string) ([]int, error) {
+	var tabIds []int
+	seen := make(map[int]bool)
+	for _, arg := range args {
+		for _, part := range strings.Split(arg, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+
+			id, err := resolveSingleTabID(part)
+			if err != nil {
+				return nil, err
+			}
+
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			tabIds = append(tabIds, id)
+		}
+	}
+
+	if len(tabIds) == 0 {
+		return nil, fmt.Errorf("no tab ids given")
+	}
+
+	return tabIds, nil
 }
 
 func NewCmdTabList(printer tableprinter.TablePrinter) *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "list",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			res, err := sendMessage(map[string]string{
-				"command": "tab.list",
-			})
+			if err := applySavedFilters(cmd); err != nil {
+				return err
+			}
+
+			var fields []string
+			if spec, _ := cmd.Flags().GetString("fields"); spec != "" {
+				names, err := parseTabFields(spec)
+				if err != nil {
+					return err
+				}
+				fields = names
+			}
+
+			var columns []string
+			if spec, _ := cmd.Flags().GetString("columns"); spec != "" {
+				names, err := parseTabFields(spec)
+				if err != nil {
+					return err
+				}
+				columns = names
+			}
+
+			var tmpl *template.Template
+			if text, _ := cmd.Flags().GetString("template"); text != "" {
+				t, err := parseTabTemplate(text)
+				if err != nil {
+					return err
+				}
+				tmpl = t
+			}
+
+			tabs, err := listWindowTabs(cmd)
 			if err != nil {
 				return err
 			}
 
-			var tabs []Tab
-			if err := json.Unmarshal(res, &tabs); err != nil {
+			tabs, err = selectTabs(cmd, tabs)
+			if err != nil {
 				return err
 			}
 
-			jsonOutput, _ := cmd.Flags().GetBool("json")
-			if jsonOutput {
-				encoder := json.NewEncoder(os.Stdout)
-				encoder.SetIndent("", "  ")
-				if err := encoder.Encode(tabs); err != nil {
+			if since, _ := cmd.Flags().GetString("since"); since != "" {
+				cutoff, err := parseSince(since)
+				if err != nil {
 					return err
 				}
-				return nil
+
+				// tabs without an access time are unknown, they are only excluded when filtering on it
+				var recent []Tab
+				for _, tab := range tabs {
+					if tab.LastAccessed != 0 && !lastAccessedTime(tab).Before(cutoff) {
+						recent = append(recent, tab)
+					}
+				}
+				tabs = recent
+			}
+
+			limit, _ := cmd.Flags().GetInt("limit")
+			offset, _ := cmd.Flags().GetInt("offset")
+			if limit < 0 || offset < 0 {
+				return fmt.Errorf("--limit and --offset must be positive")
+			}
+
+			total := len(tabs)
+			paginated := cmd.Flags().Changed("limit") || cmd.Flags().Changed("offset")
+			if paginated {
+				tabs = paginate(tabs, offset, limit)
+			}
+
+			format, err := tabOutputFormat(cmd)
+			if err != nil {
+				return err
 			}
 
+			if tree, _ := cmd.Flags().GetBool("tree"); tree {
+				if format == "csv" || format == "tsv" || fields != nil || columns != nil {
+					return fmt.Errorf("--tree can only be used with the table or json formats")
+				}
+
+				tree, err := buildTabTree(cmd.Context(), tabs)
+				if err != nil {
+					return err
+				}
+
+				if format == "json" {
+					return writeJSON(cmd, tree)
+				}
+
+				printer, closePager := pagedPrinter(cmd, printer, len(tabs)+len(tree))
+				addTabTreeRows(printer, tree)
+				if err := printer.Render(); err != nil {
+					return err
+				}
+
+				return closePager()
+			}
+
+			if tmpl != nil {
+				if format != "table" {
+					return fmt.Errorf("--template can not be used with the %s format", format)
+				}
+				return writeTabTemplate(os.Stdout, tabs, tmpl)
+			}
+
+			switch format {
+			case "csv", "tsv":
+				if columns == nil {
+					columns = defaultTabColumns
+				}
+				return writeTabRecords(os.Stdout, tabs, columns, format, showHeader(cmd, format))
+			case "json":
+				noMeta, _ := cmd.Flags().GetBool("no-meta")
+				if !paginated || noMeta {
+					return writeJSON(cmd, newTabOutputs(tabs))
+				}
+
+				return writeJSON(cmd, TabPage{
+					Total:  total,
+					Offset: offset,
+					Limit:  limit,
+					Tabs:   newTabOutputs(tabs),
+				})
+			}
+
+			if fields != nil {
+				separator, _ := cmd.Flags().GetString("separator")
+				return writeTabFields(os.Stdout, tabs, fields, separator, showHeader(cmd, "fields"))
+			}
+
+			color := colorEnabled(cmd)
+			printer, closePager := pagedPrinter(cmd, printer, len(tabs))
+			if showHeader(cmd, format) {
+				if columns != nil {
+					addHeaderRow(cmd, printer, columns)
+				} else {
+					addHeaderRow(cmd, printer, []string{"id", "title", "url", "audio"})
+				}
+			}
 			for _, tab := range tabs {
-				printer.AddField(strconv.Itoa(tab.ID))
-				printer.AddField(tab.Title)
-				printer.AddField(tab.URL)
+				var rowColor func(string) string
+				if color {
+					rowColor = tabRowColor(tab)
+				}
+
+				if columns != nil {
+					for _, name := range columns {
+						printer.AddField(tabFields[name](tab), tableprinter.WithColor(rowColor))
+					}
+					printer.EndRow()
+					continue
+				}
+
+				printer.AddField(strconv.Itoa(tab.ID), tableprinter.WithColor(rowColor))
+				printer.AddField(tab.Title, tableprinter.WithColor(rowColor))
+				printer.AddField(tab.URL, tableprinter.WithColor(rowColor))
+				switch {
+				case tab.MutedInfo.Muted:
+					printer.AddField("muted", tableprinter.WithColor(rowColor))
+				case tab.Audible && color:
+					// the dot only shows up in colors, piped output stays as it was
+					printer.AddField("●", tableprinter.WithColor(yellowColor))
+				default:
+					printer.AddField("")
+				}
 				printer.EndRow()
 			}
 
@@ -71,76 +257,151 @@ func NewCmdTabList(printer tableprinter.TablePrinter) *cobra.Command {
 				return err
 			}
 
+			if err := closePager(); err != nil {
+				return err
+			}
+
+			if paginated && len(tabs) < total && !isQuiet(cmd) {
+				if len(tabs) == 0 {
+					cmd.PrintErrf("showing 0 of %d\n", total)
+				} else {
+					cmd.PrintErrf("showing %d-%d of %d\n", offset+1, offset+len(tabs), total)
+				}
+			}
+
 			return nil
 		},
 	}
 
+	cmd.Flags().String("format", "table", "output format: table, json, csv or tsv")
 	cmd.Flags().Bool("json", false, "output as json")
+	cmd.Flags().MarkDeprecated("json", "use --format=json instead")
+	cmd.Flags().String("fields", "", "comma-separated fields to print as plain text, e.g. id,url")
+	cmd.Flags().String("separator", "\t", "separator between the --fields values")
+	addHeaderFlags(cmd)
+	cmd.Flags().String("columns", "", "comma-separated fields to show as table columns, e.g. id,url,status")
+	cmd.Flags().String("template", "", "go template to print each tab with, e.g. '{{.ID}} {{.Title | trunc 30}}'")
+	cmd.MarkFlagsMutuallyExclusive("fields", "columns", "template")
+	cmd.Flags().Bool("tree", false, "nest the tabs under their window and tab group")
+	cmd.MarkFlagsMutuallyExclusive("tree", "template")
+	cmd.Flags().Bool("pager", false, "always page the output")
+	cmd.Flags().Bool("no-pager", false, "never page the output")
+	cmd.MarkFlagsMutuallyExclusive("pager", "no-pager")
+	addSelectFlags(cmd)
+	addWindowFlags(cmd)
+	cmd.Flags().String("since", "", "only show the tabs accessed since a duration like 2h or 7d, or a date like 2006-01-02")
+	cmd.Flags().Int("limit", 0, "maximum number of tabs to show, 0 for no limit")
+	cmd.Flags().Int("offset", 0, "number of tabs to skip")
+	cmd.Flags().Bool("no-meta", false, "output a bare json array when paginating")
+	cmd.Flags().Lookup("filter").Usage = "only show tabs whose url or title contain the given text, @name applies the saved filter with the given name"
+	cmd.Flags().String("save-filter", "", "save the given flags as a named filter, recalled with --filter @<name>")
+	cmd.Flags().String("load-filter", "", "apply the flags of a saved filter")
+	cmd.Flags().MarkDeprecated("load-filter", "use --filter @<name> instead")
 
 	return cmd
 }
 
 func NewCmdTabPin() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  "pin",
-		Args: cobra.ArbitraryArgs,
+		Use:               "pin",
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completeTabIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			msg := map[string]any{
-				"command": "tab.pin",
-			}
-
+			var tabIds []int
 			if len(args) > 0 {
-				tabIds := make([]int, len(args))
-				for i, arg := range args {
-					id, err := strconv.Atoi(arg)
-					if err != nil {
-						return fmt.Errorf("invalid tab id: %w", err)
-					}
-					tabIds[i] = id
+				ids, err := resolveTabIDs(args)
+				if err != nil {
+					return err
 				}
+				tabIds = ids
+			}
 
-				msg["tabIds"] = tabIds
+			if toggle, _ := cmd.Flags().GetBool("toggle"); toggle {
+				return togglePinned(cmd, tabIds)
 			}
 
-			_, err := sendMessage(msg)
+			tabs, err := client.PinTabs(cmd.Context(), tabIds...)
 			if err != nil {
 				return err
 			}
 
+			printSummary(cmd, "pinned", affectedCount(tabs, tabIds), 0)
 			return nil
 		},
 	}
 
+	cmd.Flags().Bool("toggle", false, "unpin the tabs that are already pinned")
+
 	return cmd
 }
 
+// togglePinned pins the given tabs, or the active tab when none are given, and unpins the ones that already are.
+func togglePinned(cmd *cobra.Command, tabIds []int) error {
+	var tabs []Tab
+	if len(tabIds) > 0 {
+		res, err := client.GetTabs(cmd.Context(), tabIds...)
+		if err != nil {
+			return err
+		}
+		tabs = res
+	} else {
+		tab, err := client.GetActiveTab(cmd.Context())
+		if err != nil {
+			return err
+		}
+		tabs = []Tab{*tab}
+	}
+
+	var pin, unpin []int
+	for _, tab := range tabs {
+		if tab.Pinned {
+			unpin = append(unpin, tab.ID)
+		} else {
+			pin = append(pin, tab.ID)
+		}
+	}
+
+	// an empty list of ids would target the active tab
+	if len(pin) > 0 {
+		pinned, err := client.PinTabs(cmd.Context(), pin...)
+		if err != nil {
+			return err
+		}
+		printSummary(cmd, "pinned", affectedCount(pinned, pin), 0)
+	}
+
+	if len(unpin) > 0 {
+		unpinned, err := client.UnpinTabs(cmd.Context(), unpin...)
+		if err != nil {
+			return err
+		}
+		printSummary(cmd, "unpinned", affectedCount(unpinned, unpin), 0)
+	}
+
+	return nil
+}
+
 func NewCmdTabUnpin() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  "unpin",
-		Args: cobra.ArbitraryArgs,
+		Use:               "unpin",
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completeTabIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			msg := map[string]any{
-				"command": "tab.unpin",
-			}
-
+			var tabIds []int
 			if len(args) > 0 {
-				tabIds := make([]int, len(args))
-				for i, arg := range args {
-					id, err := strconv.Atoi(arg)
-					if err != nil {
-						return fmt.Errorf("invalid tab id: %w", err)
-					}
-					tabIds[i] = id
+				ids, err := resolveTabIDs(args)
+				if err != nil {
+					return err
 				}
-
-				msg["tabIds"] = tabIds
+				tabIds = ids
 			}
 
-			_, err := sendMessage(msg)
+			tabs, err := client.UnpinTabs(cmd.Context(), tabIds...)
 			if err != nil {
 				return err
 			}
 
+			printSummary(cmd, "unpinned", affectedCount(tabs, tabIds), 0)
 			return nil
 		},
 	}
@@ -148,74 +409,407 @@ func NewCmdTabUnpin() *cobra.Command {
 	return cmd
 }
 
-func NewCmdTabCreate() *cobra.Command {
+func NewCmdTabMute() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  "create",
-		Args: cobra.ArbitraryArgs,
+		Use:               "mute",
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completeTabIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			msg := map[string]any{
-				"command": "tab.create",
+			count := 1
+			var tabIds []int
+			if len(args) > 0 {
+				ids, err := resolveTabIDs(args)
+				if err != nil {
+					return err
+				}
+				tabIds = ids
+				count = len(ids)
 			}
 
+			if err := client.MuteTabs(cmd.Context(), tabIds...); err != nil {
+				return err
+			}
+
+			printSummary(cmd, "muted", count, 0)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func NewCmdTabUnmute() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "unmute",
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completeTabIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			count := 1
+			var tabIds []int
 			if len(args) > 0 {
-				msg["urls"] = args
+				ids, err := resolveTabIDs(args)
+				if err != nil {
+					return err
+				}
+				tabIds = ids
+				count = len(ids)
 			}
 
-			_, err := sendMessage(msg)
-			if err != nil {
+			if err := client.UnmuteTabs(cmd.Context(), tabIds...); err != nil {
 				return err
 			}
 
+			printSummary(cmd, "unmuted", count, 0)
 			return nil
 		},
 	}
 
 	return cmd
+}
+
+// getTabArg returns the tab whose id is the first argument, or the active tab when there are no arguments.
+func getTabArg(cmd *cobra.Command, args []string) (*Tab, error) {
+	if len(args) == 0 {
+		return client.GetActiveTab(cmd.Context())
+	}
+
+	tabId, err := resolveSingleTabID(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetTab(cmd.Context(), tabId)
+}
+
+// siblingTabs returns the tabs of the window of target on the given side of it: others, right or left.
+// Pinned tabs are left out unless --include-pinned is set.
+func siblingTabs(cmd *cobra.Command, target Tab, side string) ([]Tab, error) {
+	tabs, err := client.ListWindowTabs(cmd.Context(), target.WindowID)
+	if err != nil {
+		return nil, err
+	}
+
+	includePinned, _ := cmd.Flags().GetBool("include-pinned")
+	var siblings []Tab
+	for _, tab := range tabs {
+		if tab.ID == target.ID || (tab.Pinned && !includePinned) {
+			continue
+		}
+
+		if (side == "right" && tab.Index < target.Index) || (side == "left" && tab.Index > target.Index) {
+			continue
+		}
+
+		siblings = append(siblings, tab)
+	}
 
+	return siblings, nil
+}
+
+// matchingTabs returns the tabs whose url and title contain the given texts, ignoring case.
+func matchingTabs(cmd *cobra.Command, url string, title string) ([]Tab, error) {
+	tabs, err := client.ListTabs(cmd.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Tab
+	for _, tab := range tabs {
+		if url != "" && !strings.Contains(strings.ToLower(tab.URL), strings.ToLower(url)) {
+			continue
+		}
+		if title != "" && !strings.Contains(strings.ToLower(tab.Title), strings.ToLower(title)) {
+			continue
+		}
+		matches = append(matches, tab)
+	}
+
+	return matches, nil
+}
+
+// matchTab returns the only tab whose url and title contain the given texts, ignoring case.
+// When several tabs match, they are printed to stderr so the user can pick one.
+func matchTab(cmd *cobra.Command, url string, title string) (*Tab, error) {
+	matches, err := matchingTabs(cmd, url, title)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, webterm.NotFoundf("no tab matches")
+	case 1:
+		return &matches[0], nil
+	}
+
+	for _, tab := range matches {
+		cmd.PrintErrf("%d\t%s\t%s\n", tab.ID, tab.Title, tab.URL)
+	}
+
+	return nil, fmt.Errorf("%d tabs match, use a more specific pattern or a tab id", len(matches))
 }
 
 func NewCmdTabGet(printer tableprinter.TablePrinter) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  "get",
-		Args: cobra.MaximumNArgs(1),
+		Use:               "get [tabId]...",
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completeTabIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			msg := map[string]any{
-				"command": "tab.get",
+			var field func(Tab) string
+			if name, _ := cmd.Flags().GetString("field"); name != "" {
+				f, err := tabField(name)
+				if err != nil {
+					return err
+				}
+				field = f
+			}
+			if _, err := onMissingPolicy(cmd); err != nil {
+				return err
 			}
 
-			if len(args) > 0 {
-				tabId, err := strconv.Atoi(args[0])
+			if len(args) > 1 {
+				tabIds, err := resolveTabIDs(args)
 				if err != nil {
-					return fmt.Errorf("invalid tab id: %w", err)
+					return err
 				}
 
-				msg["tabId"] = tabId
+				var tabs []Tab
+				if _, err := runOnTabs(cmd, tabIds, func(tabIds []int) error {
+					tabs, err = client.GetTabs(cmd.Context(), tabIds...)
+					return err
+				}); err != nil {
+					return err
+				}
+
+				if field != nil {
+					for _, tab := range tabs {
+						fmt.Println(field(tab))
+					}
+					return nil
+				}
+
+				return writeTabs(cmd, printer, tabs)
 			}
 
-			res, err := sendMessage(msg)
+			tab, err := getTabArg(cmd, args)
+			if err != nil {
+				if len(args) > 0 {
+					if tabId, _ := resolveSingleTabID(args[0]); skipMissingTab(cmd, tabId, err) {
+						return nil
+					}
+				}
+				return err
+			}
+
+			if field != nil {
+				fmt.Println(field(*tab))
+				return nil
+			}
+
+			return writeTab(cmd, printer, *tab)
+		},
+	}
+
+	cmd.Flags().String("format", "table", "output format: table, json, csv or tsv")
+	cmd.Flags().Bool("json", false, "output as json")
+	cmd.Flags().MarkDeprecated("json", "use --format=json instead")
+	cmd.Flags().String("field", "", "print only the value of the given field")
+	addHeaderFlags(cmd)
+	addOnMissingFlag(cmd)
+
+	return cmd
+}
+
+// writeTab prints a single tab in the --format of the command.
+func writeTab(cmd *cobra.Command, printer tableprinter.TablePrinter, tab Tab) error {
+	format, err := tabOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		return writeJSON(cmd, newTabOutput(tab))
+	}
+
+	return writeTabs(cmd, printer, []Tab{tab})
+}
+
+// writeTabs prints tabs in the --format of the command, as a json array with --format=json.
+func writeTabs(cmd *cobra.Command, printer tableprinter.TablePrinter, tabs []Tab) error {
+	format, err := tabOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+		return writeJSON(cmd, newTabOutputs(tabs))
+	case "csv", "tsv":
+		return writeTabRecords(os.Stdout, tabs, defaultTabColumns, format, showHeader(cmd, format))
+	}
+
+	if showHeader(cmd, format) {
+		addHeaderRow(cmd, printer, defaultTabColumns)
+	}
+	for _, tab := range tabs {
+		printer.AddField(strconv.Itoa(tab.ID))
+		printer.AddField(tab.Title)
+		printer.AddField(tab.URL)
+		printer.EndRow()
+	}
+
+	return printer.Render()
+}
+
+func NewCmdTabDuplicate(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "duplicate",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeTabID,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tab, err := getTabArg(cmd, args)
 			if err != nil {
 				return err
 			}
 
-			var tab Tab
-			if err := json.Unmarshal(res, &tab); err != nil {
+			duplicate, err := client.DuplicateTab(cmd.Context(), tab.ID)
+			if err != nil {
 				return err
 			}
 
+			return writeTab(cmd, printer, *duplicate)
+		},
+	}
+
+	cmd.Flags().String("format", "table", "output format: table, json, csv or tsv")
+
+	return cmd
+}
+
+type TabInfo struct {
+	Tab
+	Group  *TabGroup `json:"group,omitempty"`
+	Window *Window   `json:"window,omitempty"`
+}
+
+func NewCmdTabInfo(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "info [tabId]",
+		Aliases:           []string{"inspect"},
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeTabID,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var field func(Tab) string
+			if name, _ := cmd.Flags().GetString("field"); name != "" {
+				f, err := tabField(name)
+				if err != nil {
+					return err
+				}
+				field = f
+			}
+
+			tab, err := getTabArg(cmd, args)
+			if err != nil {
+				return err
+			}
+			info := TabInfo{Tab: *tab}
+
+			if field != nil {
+				fmt.Println(field(info.Tab))
+				return nil
+			}
+
+			noResolve, _ := cmd.Flags().GetBool("no-resolve")
+			if !noResolve {
+				// the group may be gone by then, e.g. when its last tab just left it, only its id is shown
+				if info.GroupID != webterm.TabGroupNone {
+					group, err := client.GetTabGroup(cmd.Context(), info.GroupID)
+					if err != nil && !errors.Is(err, webterm.ErrNotFound) {
+						return err
+					}
+					info.Group = group
+				}
+
+				windows, err := client.ListWindows(cmd.Context())
+				if err != nil {
+					return err
+				}
+
+				for i, window := range windows {
+					if window.ID == info.WindowID {
+						info.Window = &windows[i]
+						break
+					}
+				}
+			}
+
 			jsonOutput, _ := cmd.Flags().GetBool("json")
 			if jsonOutput {
-				encoder := json.NewEncoder(os.Stdout)
-				encoder.SetIndent("", "  ")
-				if err := encoder.Encode(tab); err != nil {
+				if err := writeJSON(cmd, info); err != nil {
 					return err
 				}
 				return nil
 			}
 
-			printer.AddField(strconv.Itoa(tab.ID))
-			printer.AddField(tab.Title)
-			printer.AddField(tab.URL)
-			printer.EndRow()
+			window := strconv.Itoa(info.WindowID)
+			if info.Window != nil {
+				if info.Window.Focused {
+					window += " (focused)"
+				} else {
+					window += " (unfocused)"
+				}
+			}
+
+			group := "none"
+			if info.Group != nil {
+				title := info.Group.Title
+				if title == "" {
+					title = strconv.Itoa(info.Group.ID)
+				}
+				group = fmt.Sprintf("%s (%s", title, info.Group.Color)
+				if info.Group.Collapsed {
+					group += ", collapsed"
+				}
+				group += ")"
+			} else if info.GroupID != webterm.TabGroupNone {
+				group = strconv.Itoa(info.GroupID)
+			}
+
+			lastAccessed := "unknown"
+			if info.LastAccessed != 0 {
+				lastAccessed = lastAccessedTime(info.Tab).Format(time.RFC3339)
+			}
+
+			rows := [][2]string{
+				{"ID", strconv.Itoa(info.ID)},
+				{"Title", info.Title},
+				{"URL", info.URL},
+				{"Favicon URL", info.FavIconURL},
+				{"Status", info.Status},
+				{"Index", strconv.Itoa(info.Index)},
+				{"Active", strconv.FormatBool(info.Active)},
+				{"Highlighted", strconv.FormatBool(info.Highlighted)},
+				{"Selected", strconv.FormatBool(info.Selected)},
+				{"Pinned", strconv.FormatBool(info.Pinned)},
+				{"Audible", strconv.FormatBool(info.Audible)},
+				{"Muted", strconv.FormatBool(info.MutedInfo.Muted)},
+				{"Discarded", strconv.FormatBool(info.Discarded)},
+				{"Auto discardable", strconv.FormatBool(info.AutoDiscardable)},
+				{"Incognito", strconv.FormatBool(info.Incognito)},
+				{"Size", fmt.Sprintf("%dx%d", info.Width, info.Height)},
+				{"Last accessed", lastAccessed},
+				{"Window", window},
+				{"Group", group},
+			}
+			if info.SessionID != "" {
+				rows = append(rows, [2]string{"Session ID", info.SessionID})
+			}
+
+			for _, row := range rows {
+				printer.AddField(row[0])
+				printer.AddField(row[1])
+				printer.EndRow()
+			}
 
 			if err := printer.Render(); err != nil {
 				return err
@@ -225,37 +819,96 @@ func NewCmdTabGet(printer tableprinter.TablePrinter) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().Bool("json", false, "output as json")
+	cmd.Flags().String("field", "", "print only the value of the given field")
+	cmd.Flags().Bool("no-resolve", false, "skip the group and window lookups")
 
 	return cmd
 }
 
 func NewCmdTabUrl() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  "url",
-		Args: cobra.MaximumNArgs(1),
+		Use:               "url",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeTabID,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			msg := map[string]any{
-				"command": "tab.get",
-			}
+			all, _ := cmd.Flags().GetBool("all")
+			withId, _ := cmd.Flags().GetBool("with-id")
+			parse, _ := cmd.Flags().GetBool("parse")
+			if all {
+				if len(args) > 0 {
+					return fmt.Errorf("--all does not accept a tab id")
+				}
+				if parse || cmd.Flags().Changed("query") {
+					return fmt.Errorf("--parse and --query can not be used with --all")
+				}
 
-			if len(args) > 0 {
-				tabId, err := strconv.Atoi(args[0])
+				tabs, err := client.ListAllTabs(cmd.Context())
 				if err != nil {
-					return fmt.Errorf("invalid tab id: %w", err)
+					return err
 				}
 
-				msg["tabId"] = tabId
+				if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+					urls := make([]map[string]any, len(tabs))
+					for i, tab := range tabs {
+						urls[i] = map[string]any{
+							"id":  tab.ID,
+							"url": tab.URL,
+						}
+					}
+					return writeJSON(cmd, urls)
+				}
+
+				for _, tab := range tabs {
+					if withId {
+						fmt.Printf("%d\t%s\n", tab.ID, tab.URL)
+						continue
+					}
+					fmt.Println(tab.URL)
+				}
+				return nil
 			}
 
-			res, err := sendMessage(msg)
+			if withId {
+				return fmt.Errorf("--with-id requires --all")
+			}
+
+			tab, err := getTabArg(cmd, args)
 			if err != nil {
 				return err
 			}
 
-			var tab Tab
-			if err := json.Unmarshal(res, &tab); err != nil {
-				return err
+			if parse || cmd.Flags().Changed("query") {
+				parts, err := parseURLParts(tab.URL)
+				if err != nil {
+					return err
+				}
+
+				if cmd.Flags().Changed("query") {
+					key, _ := cmd.Flags().GetString("query")
+					values, ok := parts.Query[key]
+					if !ok {
+						return webterm.NotFoundf("no %s query parameter in %s", key, tab.URL)
+					}
+
+					for _, value := range values {
+						fmt.Println(value)
+					}
+					return nil
+				}
+
+				if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+					return writeJSON(cmd, parts)
+				}
+
+				printURLParts(parts)
+				return nil
+			}
+
+			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+				return writeJSON(cmd, map[string]any{
+					"id":  tab.ID,
+					"url": tab.URL,
+				})
 			}
 
 			fmt.Println(tab.URL)
@@ -263,98 +916,517 @@ func NewCmdTabUrl() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().Bool("all", false, "print the url of every open tab, one per line")
+	cmd.Flags().Bool("with-id", false, "prefix each url with its tab id and a tab, with --all")
+	cmd.Flags().Bool("parse", false, "print the scheme, host, path, fragment and decoded query parameters of the url")
+	cmd.Flags().String("query", "", "print the decoded values of the given query parameter")
+	cmd.MarkFlagsMutuallyExclusive("parse", "query")
+
 	return cmd
 }
 
+// closeConfirmThreshold is the number of tabs matching a pattern past which tab close asks for confirmation.
+const closeConfirmThreshold = 5
+
 func NewCmdTabClose() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  "close",
-		Args: cobra.ArbitraryArgs,
+		Use:               "close",
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completeTabIDs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			msg := map[string]any{
-				"command": "tab.remove",
+			url, _ := cmd.Flags().GetString("url")
+			title, _ := cmd.Flags().GetString("title")
+			if (url != "" || title != "") && len(args) > 0 {
+				return fmt.Errorf("--url and --title do not accept tab ids")
+			}
+			if _, err := onMissingPolicy(cmd); err != nil {
+				return err
 			}
 
-			if len(args) > 0 {
-				tabIds := make([]int, len(args))
-				for i, arg := range args {
-					id, err := strconv.Atoi(arg)
+			var side string
+			for _, name := range []string{"others", "right", "left"} {
+				if value, _ := cmd.Flags().GetBool(name); value {
+					side = name
+				}
+			}
+			if side != "" && len(args) > 1 {
+				return fmt.Errorf("--others, --right and --left accept a single tab id")
+			}
+
+			count := 1
+			var tabIds []int
+			if len(args) > 0 && side == "" {
+				ids, err := resolveTabIDs(args)
+				if err != nil {
+					return err
+				}
+				tabIds = ids
+				count = len(ids)
+			}
+
+			if side != "" || url != "" || title != "" {
+				var matches []Tab
+				if side != "" {
+					target, err := getTabArg(cmd, args)
+					if err != nil {
+						return err
+					}
+
+					matches, err = siblingTabs(cmd, *target, side)
+					if err != nil {
+						return err
+					}
+					if len(matches) == 0 {
+						printSummary(cmd, "closed", 0, 0)
+						return nil
+					}
+				} else {
+					var err error
+					matches, err = matchingTabs(cmd, url, title)
 					if err != nil {
-						return fmt.Errorf("invalid tab id: %w", err)
+						return err
 					}
-					tabIds[i] = id
+					if len(matches) == 0 {
+						return webterm.NotFoundf("no tab matches")
+					}
+				}
+
+				dryRun, _ := cmd.Flags().GetBool("dry-run")
+				for _, tab := range matches {
+					if dryRun {
+						cmd.PrintErrf("Would close tab %d: %s\n", tab.ID, tab.URL)
+					}
+					tabIds = append(tabIds, tab.ID)
 				}
+				count = len(tabIds)
 
-				msg["tabIds"] = tabIds
+				if dryRun {
+					return nil
+				}
+
+				if force, _ := cmd.Flags().GetBool("force"); !force && count > closeConfirmThreshold {
+					ok, err := confirm(cmd, fmt.Sprintf("Close %s?", pluralize(count, "tab")))
+					if err != nil {
+						return fmt.Errorf("%d tabs match, use --force to close them all", count)
+					}
+					if !ok {
+						return fmt.Errorf("aborted")
+					}
+				}
 			}
 
-			if _, err := sendMessage(msg); err != nil {
+			var closed []Tab
+			skipped, err := runOnTabs(cmd, tabIds, func(tabIds []int) error {
+				var err error
+				closed, err = client.CloseTabs(cmd.Context(), tabIds...)
+				return err
+			})
+			if err != nil {
 				return err
 			}
 
+			if closed != nil {
+				count = len(closed) + skipped
+			}
+			printSummary(cmd, "closed", count-skipped, skipped)
 			return nil
 		},
 	}
 
+	cmd.Flags().String("url", "", "close the tabs whose url contains the given text")
+	cmd.Flags().String("title", "", "close the tabs whose title contains the given text")
+	cmd.Flags().Bool("others", false, "close the other tabs of the window of the tab, the active one by default")
+	cmd.Flags().Bool("right", false, "close the tabs to the right of the tab, the active one by default")
+	cmd.Flags().Bool("left", false, "close the tabs to the left of the tab, the active one by default")
+	cmd.Flags().Bool("include-pinned", false, "also close the pinned tabs with --others, --right and --left")
+	cmd.MarkFlagsMutuallyExclusive("others", "right", "left", "url")
+	cmd.MarkFlagsMutuallyExclusive("others", "right", "left", "title")
+	cmd.Flags().Bool("dry-run", false, "print the tabs matching --url, --title, --others, --right or --left without closing them")
+	cmd.Flags().Bool("force", false, "close the matching tabs without asking for confirmation")
+	addOnMissingFlag(cmd)
+
 	return cmd
 }
 
+func NewCmdTabReload() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "reload",
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completeTabIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bypassCache, _ := cmd.Flags().GetBool("bypass-cache")
+			stagger, _ := cmd.Flags().GetDuration("stagger")
+			if ifCrashed, _ := cmd.Flags().GetBool("if-crashed"); ifCrashed {
+				cmd.Flags().Set("status", "crashed")
+			}
+
+			all, _ := cmd.Flags().GetBool("all")
+			if all || cmd.Flags().Changed("status") || cmd.Flags().Changed("if-crashed") {
+				if len(args) > 0 {
+					return fmt.Errorf("--all, --status and --if-crashed can not be used with tab ids")
+				}
+
+				tabs, err := listWindowTabs(cmd)
+				if err != nil {
+					return err
+				}
+
+				tabs, err = selectTabs(cmd, tabs)
+				if err != nil {
+					return err
+				}
+
+				return reloadStaggered(cmd, tabs, bypassCache, stagger)
+			}
+
+			if cmd.Flags().Changed("stagger") {
+				return fmt.Errorf("--stagger can only be used with --all, --status or --if-crashed")
+			}
+
+			count := 1
+			var tabIds []int
+			if len(args) > 0 {
+				ids, err := resolveTabIDs(args)
+				if err != nil {
+					return err
+				}
+				tabIds = ids
+				count = len(ids)
+			}
+
+			if err := client.ReloadTabs(cmd.Context(), bypassCache, tabIds...); err != nil {
+				return err
+			}
+
+			printSummary(cmd, "reloaded", count, 0)
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("bypass-cache", false, "ignore the browser cache when reloading")
+	cmd.Flags().Bool("all", false, "reload every tab of the window, or the ones matching the filter flags")
+	cmd.Flags().Duration("stagger", 0, "wait this long between reloads with --all, e.g. 500ms")
+	cmd.Flags().Bool("if-crashed", false, "only reload the crashed tabs and the ones showing an error page, same as --status=crashed")
+	addFilterFlags(cmd)
+	cmd.MarkFlagsMutuallyExclusive("if-crashed", "status")
+	addWindowFlags(cmd)
+
+	return cmd
+}
+
+// reloadStaggered reloads the tabs one after the other, waiting stagger between two reloads.
+// The progress is reported on stderr unless --quiet is set.
+func reloadStaggered(cmd *cobra.Command, tabs []Tab, bypassCache bool, stagger time.Duration) error {
+	for i, tab := range tabs {
+		if i > 0 && stagger > 0 {
+			select {
+			case <-time.After(stagger):
+			case <-cmd.Context().Done():
+				return cmd.Context().Err()
+			}
+		}
+
+		if err := client.ReloadTabs(cmd.Context(), bypassCache, tab.ID); err != nil {
+			return err
+		}
+
+		if !isQuiet(cmd) {
+			cmd.PrintErrf("[%d/%d] reloaded %d %s\n", i+1, len(tabs), tab.ID, tab.URL)
+		}
+	}
+
+	printSummary(cmd, "reloaded", len(tabs), 0)
+	return nil
+}
+
 func NewCmdTabFocus() *cobra.Command {
-	return &cobra.Command{
-		Use:  "focus",
-		Args: cobra.ExactArgs(1),
+	cmd := &cobra.Command{
+		Use:               "focus",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeTabID,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			tabId, err := strconv.Atoi(args[0])
+			back, _ := cmd.Flags().GetBool("back")
+			url, _ := cmd.Flags().GetString("url")
+			title, _ := cmd.Flags().GetString("title")
+			pattern := url != "" || title != ""
+			if (back || pattern) && len(args) > 0 {
+				return fmt.Errorf("--back, --url and --title do not accept a tab id")
+			}
+			if !back && !pattern && len(args) == 0 {
+				return fmt.Errorf("a tab id is required")
+			}
+			if _, err := onMissingPolicy(cmd); err != nil {
+				return err
+			}
+
+			stack, err := loadFocusHistory()
+			if err != nil {
+				return fmt.Errorf("unable to read focus history: %w", err)
+			}
+
+			active, err := client.GetActiveTab(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			if _, err := sendMessage(map[string]any{
-				"command": "tab.focus",
-				"tabId":   tabId,
-			}); err != nil {
+			var tabId int
+			if back {
+				// pop until we find a tab that still exists
+				found := false
+				for len(stack) > 0 {
+					tabId = stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					if tabId == active.ID {
+						continue
+					}
+
+					if _, err := client.GetTab(cmd.Context(), tabId); err == nil {
+						found = true
+						break
+					}
+				}
+
+				if !found {
+					if err := saveFocusHistory(stack); err != nil {
+						return fmt.Errorf("unable to write focus history: %w", err)
+					}
+					return webterm.NotFoundf("no previously focused tab")
+				}
+			} else if pattern {
+				tab, err := matchTab(cmd, url, title)
+				if err != nil {
+					return err
+				}
+				tabId = tab.ID
+			} else {
+				tabId, err = resolveSingleTabID(args[0])
+				if err != nil {
+					return err
+				}
+			}
+
+			raise := isatty.IsTerminal(os.Stdout.Fd())
+			if cmd.Flags().Changed("raise") {
+				raise, _ = cmd.Flags().GetBool("raise")
+			}
+			if noRaise, _ := cmd.Flags().GetBool("no-raise"); noRaise {
+				raise = false
+			}
+
+			if raise {
+				tab, err := client.GetTab(cmd.Context(), tabId)
+				if err != nil {
+					if len(args) > 0 && skipMissingTab(cmd, tabId, err) {
+						return nil
+					}
+					return err
+				}
+
+				if err := client.ActivateTab(cmd.Context(), tabId); err != nil {
+					return err
+				}
+
+				if err := client.FocusWindow(cmd.Context(), tab.WindowID); err != nil {
+					return err
+				}
+			} else if err := client.ActivateTab(cmd.Context(), tabId); err != nil {
+				if len(args) > 0 && skipMissingTab(cmd, tabId, err) {
+					return nil
+				}
 				return err
 			}
 
+			if active.ID != tabId {
+				stack = pushFocusHistory(stack, active.ID)
+			}
+
+			if err := saveFocusHistory(stack); err != nil {
+				return fmt.Errorf("unable to write focus history: %w", err)
+			}
+
 			return nil
 		},
 	}
+
+	cmd.Flags().Bool("back", false, "focus the previously focused tab")
+	cmd.Flags().String("url", "", "focus the tab whose url contains the given text")
+	cmd.Flags().String("title", "", "focus the tab whose title contains the given text")
+	cmd.Flags().Bool("raise", false, "also bring the window of the tab to the front, the default when stdout is a terminal")
+	cmd.Flags().Bool("no-raise", false, "leave the window of the tab in the background, same as --raise=false")
+	cmd.MarkFlagsMutuallyExclusive("back", "url")
+	cmd.MarkFlagsMutuallyExclusive("back", "title")
+	cmd.MarkFlagsMutuallyExclusive("raise", "no-raise")
+	addOnMissingFlag(cmd)
+
+	return cmd
+}
+
+var (
+	baseHrefRegexp = regexp.MustCompile(`(?i)<base\s[^>]*href\s*=`)
+	headRegexp     = regexp.MustCompile(`(?i)<head(\s[^>]*)?>`)
+	htmlRegexp     = regexp.MustCompile(`(?i)<html(\s[^>]*)?>`)
+)
+
+// injectBaseTag points relative urls of the document at pageURL, unless it declares its own base.
+func injectBaseTag(source string, pageURL string) string {
+	if baseHrefRegexp.MatchString(source) {
+		return source
+	}
+
+	tag := fmt.Sprintf(`<base href="%s">`, html.EscapeString(pageURL))
+	for _, re := range []*regexp.Regexp{headRegexp, htmlRegexp} {
+		if loc := re.FindStringIndex(source); loc != nil {
+			return source[:loc[1]] + tag + source[loc[1]:]
+		}
+	}
+
+	return tag + source
 }
 
 func NewCmdTabSource() *cobra.Command {
-	return &cobra.Command{
-		Use:  "source",
-		Args: cobra.MaximumNArgs(1),
+	cmd := &cobra.Command{
+		Use:               "source",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeTabID,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			msg := map[string]any{
 				"command": "tab.source",
 			}
 
 			if len(args) > 0 {
-				tabId, err := strconv.Atoi(args[0])
+				tabId, err := resolveSingleTabID(args[0])
 				if err != nil {
-					return fmt.Errorf("invalid tab id: %w", err)
+					return err
 				}
 
 				msg["tabId"] = tabId
 			}
 
+			rendered, _ := cmd.Flags().GetBool("rendered")
+			if !rendered {
+				msg["rendered"] = false
+			}
+
 			res, err := sendMessage(msg)
+			if rendered {
+				err = explainScriptingError(err, "--rendered=false to get the original source")
+			}
 			if err != nil {
 				return err
 			}
 
 			var source string
-			if err := json.Unmarshal(res, &source); err != nil {
+			if err := decodeResponse(res, &source); err != nil {
 				return err
 			}
 
+			inlineBase, _ := cmd.Flags().GetBool("inline-base")
+			if inlineBase {
+				tab, err := getTabArg(cmd, args)
+				if err != nil {
+					return err
+				}
+
+				source = injectBaseTag(source, tab.URL)
+			}
+
+			output, _ := cmd.Flags().GetString("output")
+			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+				if output == "" {
+					return writeJSON(cmd, map[string]string{
+						"source": source,
+					})
+				}
+
+				content, err := marshalJSON(cmd, map[string]string{
+					"source": source,
+				})
+				if err != nil {
+					return err
+				}
+				source = string(content)
+			}
+
+			if output != "" {
+				if err := os.WriteFile(output, []byte(source), 0644); err != nil {
+					return fmt.Errorf("unable to write source: %w", err)
+				}
+				return nil
+			}
+
 			if _, err := os.Stdout.WriteString(source); err != nil {
 				return err
 			}
 			return nil
 		},
 	}
+
+	cmd.Flags().StringP("output", "o", "", "file to write the source to, defaults to stdout")
+	cmd.Flags().Bool("inline-base", false, "inject a base tag so relative links resolve against the page url")
+	cmd.Flags().Bool("rendered", true, "get the current dom of the page, false to fetch its original source")
+
+	return cmd
+}
+
+type Resource struct {
+	Duration      float64 `json:"duration"`
+	InitiatorType string  `json:"initiatorType"`
+	Name          string  `json:"name"`
+	TransferSize  int     `json:"transferSize"`
+}
+
+func NewCmdTabResources(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "resources",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg := map[string]any{
+				"command": "tab.resources",
+			}
+
+			if len(args) > 0 {
+				tabId, err := resolveSingleTabID(args[0])
+				if err != nil {
+					return err
+				}
+
+				msg["tabId"] = tabId
+			}
+
+			res, err := sendMessage(msg)
+			if err != nil {
+				return explainScriptingError(err, "")
+			}
+
+			var resources []Resource
+			if err := decodeResponse(res, &resources); err != nil {
+				return err
+			}
+
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if jsonOutput {
+				if err := writeJSON(cmd, resources); err != nil {
+					return err
+				}
+				return nil
+			}
+
+			for _, resource := range resources {
+				printer.AddField(resource.InitiatorType)
+				printer.AddField(humanizeBytes(resource.TransferSize))
+				printer.AddField(resource.Name)
+				printer.EndRow()
+			}
+
+			if err := printer.Render(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
 }
 
 func NewCmdTab(printer tableprinter.TablePrinter) *cobra.Command {
@@ -362,15 +1434,60 @@ func NewCmdTab(printer tableprinter.TablePrinter) *cobra.Command {
 		Use: "tab",
 	}
 
+	// list and get also declare it, as a deprecated alias of their --format flag
+	cmd.PersistentFlags().Bool("json", false, "output as json")
+
 	cmd.AddCommand(NewCmdTabList(printer))
+	cmd.AddCommand(NewCmdTabCount())
+	cmd.AddCommand(NewCmdTabFilters(printer))
 	cmd.AddCommand(NewCmdTabFocus())
 	cmd.AddCommand(NewCmdTabCreate())
 	cmd.AddCommand(NewCmdTabClose())
+	cmd.AddCommand(NewCmdTabDedupe())
+	cmd.AddCommand(NewCmdTabDiscard())
+	cmd.AddCommand(NewCmdTabReload())
+	cmd.AddCommand(NewCmdTabMove())
+	cmd.AddCommand(NewCmdTabMoveAllToWindow())
 	cmd.AddCommand(NewCmdTabGet(printer))
+	cmd.AddCommand(NewCmdTabDuplicate(printer))
+	cmd.AddCommand(NewCmdTabRestore(printer))
+	cmd.AddCommand(NewCmdTabRecentlyClosed(printer))
+	cmd.AddCommand(NewCmdTabQuery(printer))
+	cmd.AddCommand(NewCmdTabActive(printer))
+	cmd.AddCommand(NewCmdTabActivateNext())
+	cmd.AddCommand(NewCmdTabActivatePrev())
+	cmd.AddCommand(NewCmdTabInfo(printer))
 	cmd.AddCommand(NewCmdTabUrl())
+	cmd.AddCommand(NewCmdTabNavigate(printer))
+	cmd.AddCommand(NewCmdTabSetTitle(printer))
+	cmd.AddCommand(NewCmdTabWait())
 	cmd.AddCommand(NewCmdTabPin())
 	cmd.AddCommand(NewCmdTabUnpin())
+	cmd.AddCommand(NewCmdTabMute())
+	cmd.AddCommand(NewCmdTabUnmute())
+	cmd.AddCommand(NewCmdTabAudio(printer))
 	cmd.AddCommand(NewCmdTabSource())
+	cmd.AddCommand(NewCmdTabFind())
+	cmd.AddCommand(NewCmdTabHighlight())
+	cmd.AddCommand(NewCmdTabScroll())
+	cmd.AddCommand(NewCmdTabSendKeys())
+	cmd.AddCommand(NewCmdTabGoto())
+	cmd.AddCommand(NewCmdTabWatch())
+	cmd.AddCommand(NewCmdTabMoveToGroup())
+	cmd.AddCommand(NewCmdTabExec())
+	cmd.AddCommand(NewCmdTabScreenshot())
+	cmd.AddCommand(NewCmdTabPrint())
+	cmd.AddCommand(NewCmdTabResources(printer))
+	cmd.AddCommand(NewCmdTabManage())
+	cmd.AddCommand(NewCmdTabPick(printer))
+	cmd.AddCommand(NewCmdTabGroup(printer))
+	cmd.AddCommand(NewCmdTabOrganize())
+	cmd.AddCommand(NewCmdTabDiff())
+	cmd.AddCommand(NewCmdTabSnapshot())
+	cmd.AddCommand(NewCmdTabExport())
+	cmd.AddCommand(NewCmdTabExportLauncher())
+	cmd.AddCommand(NewCmdTabHeal())
+	cmd.AddCommand(NewCmdTabZoom())
 
 	return cmd
 }
@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testTabs = `[
+	{"id": 1, "windowId": 1, "index": 0, "title": "Go", "url": "https://go.dev/doc", "active": true, "status": "complete", "groupId": -1},
+	{"id": 2, "windowId": 1, "index": 1, "title": "GitHub", "url": "https://github.com/pomdtr/webterm", "pinned": true, "status": "complete", "groupId": -1}
+]`
+
+func TestTabCommands(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		responses  map[string]string
+		wantSent   []string
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "list",
+			args:       []string{"tab", "list"},
+			responses:  map[string]string{"tab.list": testTabs},
+			wantSent:   []string{`{"command": "tab.list"}`},
+			wantStdout: "1\tGo\thttps://go.dev/doc\t\n2\tGitHub\thttps://github.com/pomdtr/webterm\t\n",
+		},
+		{
+			name:       "list header names the audio column",
+			args:       []string{"tab", "list", "--header"},
+			responses:  map[string]string{"tab.list": `[{"id": 1, "title": "Radio", "url": "https://radio.example.com", "mutedInfo": {"muted": true}}]`},
+			wantSent:   []string{`{"command": "tab.list"}`},
+			wantStdout: "ID\tTITLE\tURL\tAUDIO\n1\tRadio\thttps://radio.example.com\tmuted\n",
+		},
+		{
+			name:       "list all windows with fields",
+			args:       []string{"tab", "list", "--all-windows", "--fields", "id,domain", "--no-header"},
+			responses:  map[string]string{"tab.list": testTabs},
+			wantSent:   []string{`{"command": "tab.list", "allWindows": true}`},
+			wantStdout: "1\tgo.dev\n2\tgithub.com\n",
+		},
+		{
+			name:       "get json",
+			args:       []string{"tab", "get", "2", "--format", "json"},
+			responses:  map[string]string{"tab.get": `{"id": 2, "url": "https://github.com/pomdtr/webterm"}`},
+			wantSent:   []string{`{"command": "tab.get", "tabId": 2}`},
+			wantStdout: `"domain":"github.com"`,
+		},
+		{
+			name:       "pin",
+			args:       []string{"tab", "pin", "1", "2"},
+			wantSent:   []string{`{"command": "tab.pin", "tabIds": [1, 2]}`},
+			wantStderr: "pinned 2 tabs\n",
+		},
+		{
+			name:       "unpin the active tab",
+			args:       []string{"tab", "unpin"},
+			responses:  map[string]string{"tab.unpin": `[{"id": 2}]`},
+			wantSent:   []string{`{"command": "tab.unpin"}`},
+			wantStderr: "unpinned 1 tab\n",
+		},
+		{
+			name:       "close",
+			args:       []string{"tab", "close", "1,2"},
+			responses:  map[string]string{"tab.remove": testTabs},
+			wantSent:   []string{`{"command": "tab.remove", "tabIds": [1, 2]}`},
+			wantStderr: "closed 2 tabs\n",
+		},
+		{
+			name:     "reload bypassing the cache",
+			args:     []string{"tab", "reload", "2", "--bypass-cache", "--quiet"},
+			wantSent: []string{`{"command": "tab.reload", "tabIds": [2], "bypassCache": true}`},
+		},
+		{
+			name:       "url",
+			args:       []string{"tab", "url", "2"},
+			responses:  map[string]string{"tab.get": `{"id": 2, "url": "https://github.com/pomdtr/webterm?tab=readme"}`},
+			wantSent:   []string{`{"command": "tab.get", "tabId": 2}`},
+			wantStdout: "https://github.com/pomdtr/webterm?tab=readme\n",
+		},
+		{
+			name:       "url query parameter",
+			args:       []string{"tab", "url", "--query", "tab"},
+			responses:  map[string]string{"tab.get": `{"id": 2, "url": "https://github.com/pomdtr/webterm?tab=read%20me"}`},
+			wantSent:   []string{`{"command": "tab.get"}`},
+			wantStdout: "read me\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateConfig(t)
+
+			transport := &fakeTransport{responses: tt.responses}
+			stdout, stderr, err := runCommand(t, transport, tt.args...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+			}
+
+			transport.assertSent(t, tt.wantSent...)
+
+			if !strings.Contains(stdout, tt.wantStdout) || (tt.wantStdout == "" && stdout != "") {
+				t.Errorf("stdout = %q, want %q", stdout, tt.wantStdout)
+			}
+			if stderr != tt.wantStderr {
+				t.Errorf("stderr = %q, want %q", stderr, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestResolveTabIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []int
+		wantErr string
+	}{
+		{name: "separate arguments", args: []string{"3", "1"}, want: []int{3, 1}},
+		{name: "comma separated", args: []string{"3,1", " 2 "}, want: []int{3, 1, 2}},
+		{name: "duplicates", args: []string{"3,3", "3"}, want: []int{3}},
+		{name: "empty parts", args: []string{"3,,", ","}, want: []int{3}},
+		{name: "no arguments", args: nil, wantErr: "no tab ids given"},
+		{name: "only empty parts", args: []string{"", ","}, wantErr: "no tab ids given"},
+		{name: "invalid id", args: []string{"1,abc"}, wantErr: "invalid tab id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveTabIDs(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTabFocusInvalidID(t *testing.T) {
+	isolateConfig(t)
+
+	transport := &fakeTransport{responses: map[string]string{"tab.get": `{"id": 1}`}}
+	_, _, err := runCommand(t, transport, "tab", "focus", "abc")
+	if err == nil || !strings.Contains(err.Error(), "invalid tab id") {
+		t.Errorf("got error %v, want an invalid tab id error", err)
+	}
+}
+
+func TestTabFocusRaise(t *testing.T) {
+	raised := []string{
+		`{"command": "tab.get"}`,
+		`{"command": "tab.get", "tabId": 2}`,
+		`{"command": "tab.focus", "tabId": 2, "raise": false}`,
+		`{"command": "window.focus", "windowId": 3}`,
+	}
+	notRaised := []string{
+		`{"command": "tab.get"}`,
+		`{"command": "tab.focus", "tabId": 2, "raise": false}`,
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		terminal bool
+		wantSent []string
+	}{
+		{
+			name:     "raises the window from a terminal",
+			args:     []string{"tab", "focus", "2"},
+			terminal: true,
+			wantSent: raised,
+		},
+		{
+			name:     "leaves the window when piped",
+			args:     []string{"tab", "focus", "2"},
+			wantSent: notRaised,
+		},
+		{
+			name:     "raise when piped",
+			args:     []string{"tab", "focus", "2", "--raise"},
+			wantSent: raised,
+		},
+		{
+			name:     "no raise from a terminal",
+			args:     []string{"tab", "focus", "2", "--no-raise"},
+			terminal: true,
+			wantSent: notRaised,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateConfig(t)
+
+			transport := &fakeTransport{responses: map[string]string{"tab.get": `{"id": 2, "windowId": 3}`}}
+			var stderr string
+			var err error
+			if tt.terminal {
+				stderr, err = runCommandOnTerminal(t, transport, tt.args...)
+			} else {
+				_, stderr, err = runCommand(t, transport, tt.args...)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+			}
+
+			transport.assertSent(t, tt.wantSent...)
+		})
+	}
+}
+
+func TestTabJSONOutputsHaveDomain(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		responses map[string]string
+	}{
+		{
+			name:      "active",
+			args:      []string{"tab", "active", "--json"},
+			responses: map[string]string{"tab.query": `[{"id": 1, "url": "https://www.go.dev/doc"}]`},
+		},
+		{
+			name:      "audio list",
+			args:      []string{"audio", "list", "--json"},
+			responses: map[string]string{"tab.list": `[{"id": 1, "url": "https://www.go.dev/doc", "audible": true}]`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateConfig(t)
+
+			stdout, stderr, err := runCommand(t, &fakeTransport{responses: tt.responses}, tt.args...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+			}
+
+			if !strings.Contains(stdout, `"domain":"go.dev"`) {
+				t.Errorf("stdout = %q, want the domain of the tab", stdout)
+			}
+		})
+	}
+}
+
+func TestTabURLUsesTheActiveTab(t *testing.T) {
+	isolateConfig(t)
+
+	transport := &fakeTransport{responses: map[string]string{
+		"tab.get": `{"id": 1, "url": "https://github.com/pomdtr"}`,
+	}}
+	stdout, stderr, err := runCommand(t, transport, "tab", "url")
+	if err != nil {
+		t.Fatalf("unexpected error: %v, stderr: %s", err, stderr)
+	}
+
+	transport.assertSent(t, `{"command": "tab.get"}`)
+	if want := "https://github.com/pomdtr\n"; stdout != want {
+		t.Errorf("stdout = %q, want %q", stdout, want)
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// templateFuncs are the helpers available to --template, on top of the text/template builtins.
+var templateFuncs = template.FuncMap{
+	// trunc shortens a string to n runes, e.g. {{.Title | trunc 30}}
+	"trunc": func(n int, s string) string {
+		runes := []rune(s)
+		if n < 0 || len(runes) <= n {
+			return s
+		}
+		if n == 0 {
+			return ""
+		}
+		return string(runes[:n-1]) + "…"
+	},
+	// pad right-pads a string with spaces to n runes, e.g. {{.Title | pad 30}}
+	"pad": func(n int, s string) string {
+		if count := n - len([]rune(s)); count > 0 {
+			return s + strings.Repeat(" ", count)
+		}
+		return s
+	},
+	"json": func(v any) (string, error) {
+		content, err := json.Marshal(v)
+		return string(content), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// parseTabTemplate parses a --template, a go text/template evaluated with each tab as the dot.
+func parseTabTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("template").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// writeTabTemplate executes the template for each tab, each output being followed by a newline.
+func writeTabTemplate(w io.Writer, tabs []Tab, tmpl *template.Template) error {
+	for _, tab := range tabs {
+		if err := tmpl.Execute(w, newTabOutput(tab)); err != nil {
+			return fmt.Errorf("unable to execute template: %w", err)
+		}
+
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdTabSetTitle(printer tableprinter.TablePrinter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "set-title <title>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			title := args[0]
+			if allowEmpty, _ := cmd.Flags().GetBool("allow-empty"); title == "" && !allowEmpty {
+				return fmt.Errorf("the title is empty, pass --allow-empty to clear it")
+			}
+
+			var tabId int
+			if cmd.Flags().Changed("tab") {
+				tabId, _ = cmd.Flags().GetInt("tab")
+			} else {
+				tab, err := client.GetActiveTab(cmd.Context())
+				if err != nil {
+					return err
+				}
+				tabId = tab.ID
+			}
+
+			tab, err := client.SetTabTitle(cmd.Context(), tabId, title)
+			if err != nil {
+				return explainScriptingError(err, "")
+			}
+
+			return writeTab(cmd, printer, *tab)
+		},
+	}
+
+	cmd.Flags().Int("tab", 0, "id of the tab to rename, defaults to the active tab")
+	cmd.Flags().Bool("allow-empty", false, "allow clearing the title")
+	cmd.Flags().String("format", "table", "output format: table, json, csv or tsv")
+
+	return cmd
+}
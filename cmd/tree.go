@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/pomdtr/webterm/webterm"
+)
+
+// TabTreeWindow is a window in the json output of tab list --tree.
+type TabTreeWindow struct {
+	ID      int            `json:"id"`
+	Focused bool           `json:"focused"`
+	Groups  []TabTreeGroup `json:"groups"`
+	// Tabs are the tabs of the window which are not part of a group.
+	Tabs []TabOutput `json:"tabs"`
+}
+
+// TabTreeGroup is a tab group in the json output of tab list --tree.
+type TabTreeGroup struct {
+	ID        int         `json:"id"`
+	Title     string      `json:"title"`
+	Color     string      `json:"color"`
+	Collapsed bool        `json:"collapsed"`
+	Tabs      []TabOutput `json:"tabs"`
+}
+
+// buildTabTree nests tabs under their window and group, windows and groups keep the order of their first tab.
+func buildTabTree(ctx context.Context, tabs []Tab) ([]TabTreeWindow, error) {
+	windows, err := client.ListWindows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	focused := make(map[int]bool)
+	for _, window := range windows {
+		focused[window.ID] = window.Focused
+	}
+
+	groups := make(map[int]TabGroup)
+	for _, tab := range tabs {
+		if tab.GroupID != webterm.TabGroupNone {
+			// only fetch the groups when a tab is part of one
+			all, err := client.ListTabGroups(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, group := range all {
+				groups[group.ID] = group
+			}
+			break
+		}
+	}
+
+	var tree []TabTreeWindow
+	windowIndex := make(map[int]int)
+	groupIndex := make(map[int]int)
+	for _, tab := range tabs {
+		i, ok := windowIndex[tab.WindowID]
+		if !ok {
+			i = len(tree)
+			windowIndex[tab.WindowID] = i
+			tree = append(tree, TabTreeWindow{
+				ID:      tab.WindowID,
+				Focused: focused[tab.WindowID],
+				Groups:  []TabTreeGroup{},
+				Tabs:    []TabOutput{},
+			})
+		}
+		window := &tree[i]
+
+		if tab.GroupID == webterm.TabGroupNone {
+			window.Tabs = append(window.Tabs, newTabOutput(tab))
+			continue
+		}
+
+		j, ok := groupIndex[tab.GroupID]
+		if !ok {
+			group := groups[tab.GroupID]
+			j = len(window.Groups)
+			groupIndex[tab.GroupID] = j
+			window.Groups = append(window.Groups, TabTreeGroup{
+				ID:        tab.GroupID,
+				Title:     group.Title,
+				Color:     group.Color,
+				Collapsed: group.Collapsed,
+			})
+		}
+		window.Groups[j].Tabs = append(window.Groups[j].Tabs, newTabOutput(tab))
+	}
+
+	if tree == nil {
+		tree = []TabTreeWindow{}
+	}
+
+	return tree, nil
+}
+
+// addTabTreeRows adds a row per window, group and tab of the tree to the printer, the active tabs are marked with a star.
+func addTabTreeRows(printer tableprinter.TablePrinter, tree []TabTreeWindow) {
+	addTabRows := func(tabs []TabOutput, depth int) {
+		for _, tab := range tabs {
+			marker := "  "
+			if tab.Active {
+				marker = "* "
+			}
+
+			printer.AddField(strconv.Itoa(tab.ID))
+			printer.AddField(strings.Repeat("  ", depth) + marker + tab.Title)
+			printer.AddField(tab.URL)
+			printer.EndRow()
+		}
+	}
+
+	for _, window := range tree {
+		title := "Window"
+		if window.Focused {
+			title += " (focused)"
+		}
+		printer.AddField(strconv.Itoa(window.ID))
+		printer.AddField(title)
+		printer.AddField("")
+		printer.EndRow()
+
+		for _, group := range window.Groups {
+			title := group.Title
+			if title == "" {
+				title = "Unnamed group"
+			}
+
+			printer.AddField(strconv.Itoa(group.ID))
+			printer.AddField(fmt.Sprintf("  %s (%s)", title, group.Color))
+			printer.AddField("")
+			printer.EndRow()
+
+			addTabRows(group.Tabs, 2)
+		}
+
+		addTabRows(window.Tabs, 1)
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// URLParts is the json output of tab url --parse.
+type URLParts struct {
+	Scheme   string              `json:"scheme"`
+	User     string              `json:"user,omitempty"`
+	Host     string              `json:"host"`
+	Port     string              `json:"port,omitempty"`
+	Path     string              `json:"path"`
+	Query    map[string][]string `json:"query"`
+	Fragment string              `json:"fragment,omitempty"`
+}
+
+// parseURLParts splits a url into its components, the path, query and fragment being decoded.
+func parseURLParts(rawURL string) (*URLParts, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url %s: %w", rawURL, err)
+	}
+
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query in %s: %w", rawURL, err)
+	}
+
+	return &URLParts{
+		Scheme:   u.Scheme,
+		User:     u.User.Username(),
+		Host:     u.Hostname(),
+		Port:     u.Port(),
+		Path:     u.Path,
+		Query:    query,
+		Fragment: u.Fragment,
+	}, nil
+}
+
+// printURLParts prints a component per line, then each query parameter sorted by key.
+func printURLParts(parts *URLParts) {
+	fmt.Printf("scheme\t%s\n", parts.Scheme)
+	if parts.User != "" {
+		fmt.Printf("user\t%s\n", parts.User)
+	}
+	fmt.Printf("host\t%s\n", parts.Host)
+	if parts.Port != "" {
+		fmt.Printf("port\t%s\n", parts.Port)
+	}
+	fmt.Printf("path\t%s\n", parts.Path)
+	if parts.Fragment != "" {
+		fmt.Printf("fragment\t%s\n", parts.Fragment)
+	}
+
+	keys := make([]string, 0, len(parts.Query))
+	for key := range parts.Query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range parts.Query[key] {
+			fmt.Printf("query\t%s=%s\n", key, value)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pomdtr/webterm/server"
+	"github.com/spf13/cobra"
+)
+
+// version is set at build time with -ldflags "-X github.com/pomdtr/webterm/cmd.version=...".
+var version string
+
+// cliVersion returns the version the cli was released as, or the module version it was built from.
+func cliVersion() string {
+	if version != "" {
+		return version
+	}
+
+	return server.Version()
+}
+
+type versionInfo struct {
+	CLI              string `json:"cli"`
+	Connected        bool   `json:"connected"`
+	HostVersion      string `json:"hostVersion,omitempty"`
+	Protocol         string `json:"protocol,omitempty"`
+	ExtensionVersion string `json:"extensionVersion,omitempty"`
+	Browser          string `json:"browser,omitempty"`
+}
+
+func NewCmdVersion() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "version",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info := versionInfo{
+				CLI: cliVersion(),
+			}
+
+			// an unreachable browser is reported, not treated as a failure
+			host, err := client.Ping(cmd.Context())
+			if host != nil {
+				info.HostVersion = host.HostVersion
+				info.Protocol = host.Protocol
+				if err == nil {
+					info.Connected = true
+					info.ExtensionVersion = host.ExtensionVersion
+					info.Browser = host.Browser
+				}
+			}
+
+			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+				return writeJSON(cmd, info)
+			}
+
+			fmt.Printf("webterm: %s\n", info.CLI)
+			if host == nil {
+				fmt.Printf("native host: not connected\n")
+			} else {
+				fmt.Printf("native host: %s (protocol %s)\n", info.HostVersion, info.Protocol)
+			}
+
+			if info.Connected {
+				fmt.Printf("extension: %s (%s)\n", info.ExtensionVersion, info.Browser)
+			} else {
+				fmt.Printf("extension: not connected\n")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("json", false, "output as json")
+
+	return cmd
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdTabWait() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "wait [tabId]",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeTabID,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			interval, _ := cmd.Flags().GetDuration("interval")
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+
+			timeout, err := resolveTimeout(cmd)
+			if err != nil {
+				return err
+			}
+
+			tab, err := getTabArg(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			if _, err := waitForTab(cmd.Context(), tab.ID, interval, timeout); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Duration("interval", 500*time.Millisecond, "how often to check the tab status")
+
+	return cmd
+}
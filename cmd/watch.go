@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pomdtr/webterm/webterm"
+	"github.com/spf13/cobra"
+)
+
+// parseTabEventTypes parses a comma-separated list of event types, e.g. created,removed.
+func parseTabEventTypes(spec string) (map[string]bool, error) {
+	types := make(map[string]bool)
+	for _, part := range strings.Split(spec, ",") {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if name == "" {
+			continue
+		}
+
+		valid := false
+		for _, eventType := range webterm.TabEventTypes {
+			if name == eventType {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid event: %s, expected one of %s", name, strings.Join(webterm.TabEventTypes, ", "))
+		}
+
+		types[name] = true
+	}
+
+	return types, nil
+}
+
+func NewCmdTabWatch() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "watch",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var types map[string]bool
+			if spec, _ := cmd.Flags().GetString("events"); spec != "" {
+				t, err := parseTabEventTypes(spec)
+				if err != nil {
+					return err
+				}
+				types = t
+			}
+
+			// one event per line, whatever --pretty says
+			encoder := json.NewEncoder(os.Stdout)
+			err := client.Watch(cmd.Context(), func(event webterm.TabEvent) error {
+				if types != nil && !types[event.Type] {
+					return nil
+				}
+
+				return encoder.Encode(event)
+			})
+			if err != nil && cmd.Context().Err() != nil {
+				// interrupting is the expected way to stop watching
+				return nil
+			}
+
+			return err
+		},
+	}
+
+	cmd.Flags().String("events", "", fmt.Sprintf("comma-separated events to print, defaults to all of them: %s", strings.Join(webterm.TabEventTypes, ", ")))
+
+	return cmd
+}
@@ -1,48 +1,28 @@
 package cmd
 
 import (
-	"encoding/json"
-	"os"
+	"fmt"
 	"strconv"
 
 	"github.com/cli/go-gh/v2/pkg/tableprinter"
+	"github.com/pomdtr/webterm/webterm"
 	"github.com/spf13/cobra"
 )
 
-type Window struct {
-	AlwaysOnTop bool   `json:"alwaysOnTop"`
-	Focused     bool   `json:"focused"`
-	Height      int    `json:"height"`
-	ID          int    `json:"id"`
-	Incognito   bool   `json:"incognito"`
-	Left        int    `json:"left"`
-	State       string `json:"state"`
-	Top         int    `json:"top"`
-	Type        string `json:"type"`
-	Width       int    `json:"width"`
-}
+type Window = webterm.Window
 
 func NewCmdWindowList(printer tableprinter.TablePrinter) *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "list",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			res, err := sendMessage(map[string]string{
-				"command": "window.list",
-			})
+			windows, err := client.ListWindows(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			var windows []Window
-			if err := json.Unmarshal(res, &windows); err != nil {
-				return err
-			}
-
 			outputJSON, _ := cmd.Flags().GetBool("json")
 			if outputJSON {
-				encoder := json.NewEncoder(os.Stdout)
-				encoder.SetIndent("", "  ")
-				if err := encoder.Encode(windows); err != nil {
+				if err := writeJSON(cmd, windows); err != nil {
 					return err
 				}
 
@@ -69,12 +49,109 @@ func NewCmdWindowList(printer tableprinter.TablePrinter) *cobra.Command {
 	return cmd
 }
 
+// parseWindowID parses a window id argument.
+func parseWindowID(arg string) (int, error) {
+	windowId, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, fmt.Errorf("invalid window id: %w", err)
+	}
+
+	return windowId, nil
+}
+
+func NewCmdWindowFocus() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "focus <windowId>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			windowId, err := parseWindowID(args[0])
+			if err != nil {
+				return err
+			}
+
+			return client.FocusWindow(cmd.Context(), windowId)
+		},
+	}
+
+	return cmd
+}
+
+func NewCmdWindowCreate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "create [url]...",
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			urls := make([]string, len(args))
+			for i, arg := range args {
+				url, err := normalizeURL(arg)
+				if err != nil {
+					return err
+				}
+				urls[i] = url
+			}
+
+			incognito, _ := cmd.Flags().GetBool("incognito")
+			window, err := client.CreateWindow(cmd.Context(), urls, &webterm.CreateWindowOptions{
+				Incognito: incognito,
+			})
+			if err != nil {
+				return err
+			}
+
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if jsonOutput {
+				return writeJSON(cmd, window)
+			}
+
+			fmt.Println(window.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("json", false, "output as json")
+	cmd.Flags().Bool("incognito", false, "open an incognito window")
+
+	return cmd
+}
+
+func NewCmdWindowClose() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "close <windowId>...",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var windowIds []int
+			for _, arg := range args {
+				windowId, err := parseWindowID(arg)
+				if err != nil {
+					return err
+				}
+				windowIds = append(windowIds, windowId)
+			}
+
+			for _, windowId := range windowIds {
+				if err := client.CloseWindow(cmd.Context(), windowId); err != nil {
+					return err
+				}
+			}
+
+			cmd.Printf("Closed %s\n", pluralize(len(windowIds), "window"))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
 func NewCmdWindow(printer tableprinter.TablePrinter) *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "window",
 	}
 
 	cmd.AddCommand(NewCmdWindowList(printer))
+	cmd.AddCommand(NewCmdWindowFocus())
+	cmd.AddCommand(NewCmdWindowCreate())
+	cmd.AddCommand(NewCmdWindowClose())
+	cmd.AddCommand(NewCmdWindowPreset(printer))
 
 	return cmd
 }
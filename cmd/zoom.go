@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pomdtr/webterm/webterm"
+	"github.com/spf13/cobra"
+)
+
+func parseZoomFactor(arg string) (float64, error) {
+	factor, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid zoom factor: %w", err)
+	}
+
+	if factor <= 0 {
+		return 0, fmt.Errorf("invalid zoom factor: %s, it must be positive", arg)
+	}
+
+	return factor, nil
+}
+
+func NewCmdTabZoomSet() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "set <factor>",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			factor, err := parseZoomFactor(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := map[string]any{
+				"command":    "tab.setZoom",
+				"zoomFactor": factor,
+			}
+
+			all, _ := cmd.Flags().GetBool("all")
+			if cmd.Flags().Changed("window") && !all {
+				return fmt.Errorf("--window requires --all")
+			}
+
+			if all {
+				if cmd.Flags().Changed("tab") {
+					return fmt.Errorf("--tab and --all are mutually exclusive")
+				}
+
+				listMsg := map[string]any{
+					"command": "tab.list",
+				}
+				if cmd.Flags().Changed("window") {
+					windowId, _ := cmd.Flags().GetInt("window")
+					listMsg["windowId"] = windowId
+				}
+
+				res, err := sendMessage(listMsg)
+				if err != nil {
+					return err
+				}
+
+				tabs, err := webterm.DecodeTabs(res)
+				if err != nil {
+					return err
+				}
+
+				tabIds := make([]int, len(tabs))
+				for i, tab := range tabs {
+					tabIds[i] = tab.ID
+				}
+				msg["tabIds"] = tabIds
+			} else if cmd.Flags().Changed("tab") {
+				tabId, _ := cmd.Flags().GetInt("tab")
+				msg["tabIds"] = []int{tabId}
+			}
+
+			if _, err := sendMessage(msg); err != nil {
+				return err
+			}
+
+			if all {
+				cmd.Printf("Set zoom to %g on %d tabs\n", factor, len(msg["tabIds"].([]int)))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int("tab", 0, "id of the tab to zoom, defaults to the active tab")
+	cmd.Flags().Bool("all", false, "zoom every tab of the window")
+	cmd.Flags().Int("window", 0, "with --all, the window to zoom instead of the current one")
+
+	return cmd
+}
+
+// formatZoomFactor prints a zoom factor as a decimal number, 1 is printed as 1.0.
+func formatZoomFactor(factor float64) string {
+	s := strconv.FormatFloat(factor, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+
+	return s
+}
+
+func NewCmdTabZoom() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "zoom [factor]",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reset, _ := cmd.Flags().GetBool("reset")
+			if reset && len(args) > 0 {
+				return fmt.Errorf("a zoom factor and --reset are mutually exclusive")
+			}
+
+			if len(args) == 0 && !reset {
+				msg := map[string]any{
+					"command": "tab.getZoom",
+				}
+				if cmd.Flags().Changed("tab") {
+					tabId, _ := cmd.Flags().GetInt("tab")
+					msg["tabId"] = tabId
+				}
+
+				res, err := sendMessage(msg)
+				if err != nil {
+					return err
+				}
+
+				var factor float64
+				if err := decodeResponse(res, &factor); err != nil {
+					return err
+				}
+
+				fmt.Println(formatZoomFactor(factor))
+				return nil
+			}
+
+			// a zoom factor of 0 resets the tab to the default zoom
+			var factor float64
+			if !reset {
+				f, err := parseZoomFactor(args[0])
+				if err != nil {
+					return err
+				}
+				factor = f
+			}
+
+			msg := map[string]any{
+				"command":    "tab.setZoom",
+				"zoomFactor": factor,
+			}
+			if cmd.Flags().Changed("tab") {
+				tabId, _ := cmd.Flags().GetInt("tab")
+				msg["tabIds"] = []int{tabId}
+			}
+
+			if _, err := sendMessage(msg); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int("tab", 0, "id of the tab, defaults to the active tab")
+	cmd.Flags().Bool("reset", false, "reset the tab to the default zoom")
+
+	cmd.AddCommand(NewCmdTabZoomSet())
+
+	return cmd
+}
@@ -2,6 +2,7 @@ import browser from "webextension-polyfill";
 
 type Message = {
   id: string;
+  requestId?: string;
   payload?: {
     command: string;
     [key: string]: any;
@@ -32,23 +33,84 @@ port.onMessage.addListener(async (msg: Message) => {
     const res = await handleMessage(msg.payload);
     port.postMessage({
       id: msg.id,
+      requestId: msg.requestId,
       payload: res,
     });
   } catch (e: any) {
     port.postMessage({
       id: msg.id,
+      requestId: msg.requestId,
       error: e.message,
     });
   }
 });
 
+// tab events are only forwarded to the host while a cli watches them
+let watchingEvents = false;
+
+function sendEvent(event: { type: string; [key: string]: any }) {
+  if (watchingEvents) {
+    port.postMessage({ id: "", event });
+  }
+}
+
+browser.tabs.onCreated.addListener((tab) => {
+  sendEvent({ type: "created", tabId: tab.id, windowId: tab.windowId, tab });
+});
+
+browser.tabs.onUpdated.addListener((tabId, changeInfo, tab) => {
+  sendEvent({
+    type: "updated",
+    tabId,
+    windowId: tab.windowId,
+    tab,
+    changeInfo,
+  });
+});
+
+browser.tabs.onRemoved.addListener((tabId, { windowId }) => {
+  sendEvent({ type: "removed", tabId, windowId });
+});
+
+browser.tabs.onActivated.addListener(({ tabId, windowId }) => {
+  sendEvent({ type: "activated", tabId, windowId });
+});
+
 async function handleMessage(payload: any): Promise<any> {
   switch (payload.command) {
+    case "events.subscribe": {
+      watchingEvents = true;
+      return;
+    }
+    case "events.unsubscribe": {
+      watchingEvents = false;
+      return;
+    }
     case "tab.list": {
+      if (payload.allWindows) {
+        return await browser.tabs.query({});
+      }
+      if (payload.windowId !== undefined) {
+        return await browser.tabs.query({ windowId: payload.windowId });
+      }
       return await browser.tabs.query({ currentWindow: true });
     }
+    case "tab.query": {
+      if (payload.queryInfo !== undefined) {
+        return await browser.tabs.query(payload.queryInfo);
+      }
+      const queryInfo = { ...payload };
+      delete queryInfo.command;
+      return await browser.tabs.query(queryInfo);
+    }
     case "tab.get": {
       let { tabId } = payload;
+      const { tabIds } = payload;
+      if (tabIds !== undefined) {
+        return await Promise.all(
+          tabIds.map((tabId: number) => browser.tabs.get(tabId))
+        );
+      }
       if (tabId === undefined) {
         tabId = await getActiveTabId();
       }
@@ -60,11 +122,12 @@ async function handleMessage(payload: any): Promise<any> {
         tabIds = [await getActiveTabId()];
       }
 
+      const tabs = [];
       for (const tabId of tabIds) {
-        await browser.tabs.update(tabId, { pinned: true });
+        tabs.push(await browser.tabs.update(tabId, { pinned: true }));
       }
 
-      return;
+      return tabs;
     }
     case "tab.unpin": {
       let { tabIds } = payload;
@@ -72,56 +135,197 @@ async function handleMessage(payload: any): Promise<any> {
         tabIds = [await getActiveTabId()];
       }
 
+      const tabs = [];
+      for (const tabId of tabIds) {
+        tabs.push(await browser.tabs.update(tabId, { pinned: false }));
+      }
+
+      return tabs;
+    }
+    case "tab.mute": {
+      let { tabIds } = payload;
+      if (tabIds === undefined) {
+        tabIds = [await getActiveTabId()];
+      }
+
+      for (const tabId of tabIds) {
+        await browser.tabs.update(tabId, { muted: true });
+      }
+
+      return;
+    }
+    case "tab.unmute": {
+      let { tabIds } = payload;
+      if (tabIds === undefined) {
+        tabIds = [await getActiveTabId()];
+      }
+
       for (const tabId of tabIds) {
-        await browser.tabs.update(tabId, { pinned: false });
+        await browser.tabs.update(tabId, { muted: false });
       }
 
       return;
     }
+    case "tab.duplicate": {
+      let { tabId } = payload;
+      if (tabId === undefined) {
+        tabId = await getActiveTabId();
+      }
+      return await browser.tabs.duplicate(tabId);
+    }
     case "tab.focus": {
-      const { tabId } = payload;
+      const { tabId, raise = true } = payload;
       const tab = await browser.tabs.update(tabId, { active: true });
-      if (tab.windowId !== undefined) {
+      if (raise && tab.windowId !== undefined) {
         await browser.windows.update(tab.windowId, { focused: true });
       }
       return;
     }
+    case "tab.move": {
+      const { tabIds, windowId, index } = payload;
+      const tabs = await browser.tabs.move(tabIds, { windowId, index });
+      return Array.isArray(tabs) ? tabs : [tabs];
+    }
+    case "tab.ungroup": {
+      const { tabIds } = payload;
+      await chrome.tabs.ungroup(tabIds);
+      return;
+    }
     case "tab.remove": {
       let { tabIds } = payload;
       if (tabIds === undefined) {
         tabIds = [await getActiveTabId()];
       }
+      // fetched first to report the closed tabs, a missing tab fails like tabs.remove would
+      const tabs = await Promise.all(
+        tabIds.map((tabId: number) => browser.tabs.get(tabId))
+      );
       await browser.tabs.remove(tabIds);
-      return;
+      return tabs;
     }
     case "tab.reload": {
       let { tabIds } = payload;
+      const { bypassCache } = payload;
       if (tabIds === undefined) {
         tabIds = [await getActiveTabId()];
       }
       for (const tabId of tabIds) {
-        await browser.tabs.reload(tabId);
+        await browser.tabs.reload(tabId, { bypassCache: !!bypassCache });
       }
       return;
     }
+    case "tab.isError": {
+      const { tabId } = payload;
+      try {
+        await chrome.scripting.executeScript({
+          target: { tabId },
+          func: () => true,
+        });
+        return false;
+      } catch (e: any) {
+        // chrome refuses to script its network error pages and the tabs whose renderer crashed
+        return /error page|crash/i.test(e.message);
+      }
+    }
+    case "tab.getZoom": {
+      let { tabId } = payload;
+      if (tabId === undefined) {
+        tabId = await getActiveTabId();
+      }
+      return await browser.tabs.getZoom(tabId);
+    }
+    case "tab.setZoom": {
+      let { tabIds, zoomFactor } = payload;
+      if (tabIds === undefined) {
+        tabIds = [await getActiveTabId()];
+      }
+
+      for (const tabId of tabIds) {
+        await browser.tabs.setZoom(tabId, zoomFactor);
+      }
+
+      return;
+    }
     case "tab.update": {
       const { tabId, url } = payload;
-      await browser.tabs.update(tabId, { url });
-      return;
+      return await browser.tabs.update(tabId, { url });
+    }
+    case "tab.goBack": {
+      const { tabId } = payload;
+      await browser.tabs.goBack(tabId);
+      return await browser.tabs.get(tabId);
+    }
+    case "tab.goForward": {
+      const { tabId } = payload;
+      await browser.tabs.goForward(tabId);
+      return await browser.tabs.get(tabId);
     }
     case "tab.create": {
-      const { urls } = payload;
-      const currentWindow = await browser.windows.getCurrent();
-      if (currentWindow.id === undefined) {
-        throw new Error("Current window not found");
+      const { urls, active, pinned, cookieStoreId, incognito } = payload;
+      let { windowId } = payload;
+      if (
+        urls.some((url: string) => url.startsWith("file://")) &&
+        !(await browser.extension.isAllowedFileSchemeAccess())
+      ) {
+        throw new Error(
+          "The extension is not allowed to open file urls, enable 'Allow access to file URLs' in its settings"
+        );
+      }
+
+      if (incognito) {
+        await checkIncognitoAccess();
+      }
+
+      if (incognito && windowId === undefined) {
+        const windows = await browser.windows.getAll({
+          windowTypes: ["normal"],
+        });
+        windowId = windows.find((window) => window.incognito)?.id;
+        if (windowId === undefined) {
+          const window = await browser.windows.create({ url: urls, incognito });
+          return window.tabs ?? [];
+        }
+      }
+
+      if (windowId === undefined) {
+        const currentWindow = await browser.windows.getCurrent();
+        if (currentWindow.id === undefined) {
+          throw new Error("Current window not found");
+        }
+        windowId = currentWindow.id;
       }
 
+      const tabs = [];
       for (const url of urls) {
-        await browser.tabs.create({ url, windowId: currentWindow.id });
+        tabs.push(
+          await browser.tabs.create({
+            url,
+            active,
+            pinned,
+            cookieStoreId,
+            windowId,
+          })
+        );
       }
 
-      await browser.windows.update(currentWindow.id, { focused: true });
-      return;
+      // tabs opened in the background leave the focus where it is
+      if (active !== false) {
+        await browser.windows.update(windowId, { focused: true });
+      }
+      return tabs;
+    }
+    case "tab.discard": {
+      let { tabIds } = payload;
+      if (tabIds === undefined) {
+        tabIds = [await getActiveTabId()];
+      }
+
+      const tabs = [];
+      for (const tabId of tabIds) {
+        tabs.push(await chrome.tabs.discard(tabId));
+      }
+
+      return tabs;
     }
     case "tab.source": {
       let { tabId } = payload;
@@ -129,6 +333,21 @@ async function handleMessage(payload: any): Promise<any> {
         tabId = await getActiveTabId();
       }
 
+      // the original source is fetched again, which works on pages that can not be scripted
+      if (payload.rendered === false) {
+        const tab = await browser.tabs.get(tabId);
+        if (tab.url === undefined) {
+          throw new Error("Tab url not available");
+        }
+
+        const res = await fetch(tab.url, { credentials: "include" });
+        if (!res.ok) {
+          throw new Error(`Unable to fetch ${tab.url}: ${res.status}`);
+        }
+
+        return await res.text();
+      }
+
       const res = await chrome.scripting.executeScript({
         target: { tabId },
         func: () => {
@@ -138,6 +357,365 @@ async function handleMessage(payload: any): Promise<any> {
 
       return res[0].result;
     }
+    case "tab.screenshot": {
+      let { tabId } = payload;
+      const { scale, format = "png", quality } = payload;
+      if (tabId === undefined) {
+        tabId = await getActiveTabId();
+      }
+
+      if (scale === undefined) {
+        const tab = await browser.tabs.get(tabId);
+        if (!tab.active) {
+          throw new Error("Only the active tab of a window can be captured without --scale");
+        }
+
+        const dataUrl = await browser.tabs.captureVisibleTab(tab.windowId, {
+          format,
+          quality,
+        });
+        return dataUrl.slice(dataUrl.indexOf(",") + 1);
+      }
+
+      // the capture scale can only be set through the devtools protocol
+      const target = { tabId };
+      await chrome.debugger.attach(target, "1.3");
+      try {
+        await chrome.debugger.sendCommand(
+          target,
+          "Emulation.setDeviceMetricsOverride",
+          { width: 0, height: 0, deviceScaleFactor: scale, mobile: false }
+        );
+        const res = (await chrome.debugger.sendCommand(
+          target,
+          "Page.captureScreenshot",
+          { format, quality }
+        )) as { data: string };
+        await chrome.debugger.sendCommand(
+          target,
+          "Emulation.clearDeviceMetricsOverride"
+        );
+        return res.data;
+      } finally {
+        await chrome.debugger.detach(target);
+      }
+    }
+    case "tab.printToPDF": {
+      let { tabId } = payload;
+      const { landscape = false, printBackground = false, scale = 1 } = payload;
+      if (tabId === undefined) {
+        tabId = await getActiveTabId();
+      }
+
+      // pages can only be printed to pdf through the devtools protocol
+      const target = { tabId };
+      await chrome.debugger.attach(target, "1.3");
+      try {
+        const res = (await chrome.debugger.sendCommand(
+          target,
+          "Page.printToPDF",
+          { landscape, printBackground, scale }
+        )) as { data: string };
+        return res.data;
+      } finally {
+        await chrome.debugger.detach(target);
+      }
+    }
+    case "tab.exec": {
+      let { tabId } = payload;
+      const { code } = payload;
+      if (tabId === undefined) {
+        tabId = await getActiveTabId();
+      }
+
+      // the code runs in the page so it can reach its globals, and is subject to its csp
+      const res = await chrome.scripting.executeScript({
+        target: { tabId },
+        world: "MAIN",
+        args: [code],
+        func: (code: string) => {
+          return (0, eval)(code);
+        },
+      });
+
+      return res[0].result ?? null;
+    }
+    case "tab.highlight": {
+      let { windowId } = payload;
+      const { tabs } = payload;
+      if (windowId === undefined) {
+        windowId = (await browser.windows.getCurrent()).id;
+      }
+
+      await browser.tabs.highlight({ windowId, tabs });
+      return await browser.tabs.query({ windowId, highlighted: true });
+    }
+    case "tab.setTitle": {
+      let { tabId } = payload;
+      const { title } = payload;
+      if (tabId === undefined) {
+        tabId = await getActiveTabId();
+      }
+
+      await chrome.scripting.executeScript({
+        target: { tabId },
+        args: [title],
+        func: (title: string) => {
+          document.title = title;
+        },
+      });
+
+      // the tab picks up the new title asynchronously
+      let tab = await browser.tabs.get(tabId);
+      for (let i = 0; i < 10 && tab.title !== title; i++) {
+        await new Promise((resolve) => setTimeout(resolve, 50));
+        tab = await browser.tabs.get(tabId);
+      }
+
+      return tab;
+    }
+    case "tab.scroll": {
+      let { tabId } = payload;
+      const { to, by, selector } = payload;
+      if (tabId === undefined) {
+        tabId = await getActiveTabId();
+      }
+
+      const res = await chrome.scripting.executeScript({
+        target: { tabId },
+        args: [to ?? null, by ?? null, selector ?? null],
+        func: (
+          to: string | null,
+          by: number | null,
+          selector: string | null,
+        ) => {
+          const root = document.scrollingElement ?? document.documentElement;
+          if (selector !== null) {
+            const element = document.querySelector(selector);
+            if (element === null) {
+              return `No element matches ${selector}`;
+            }
+            element.scrollIntoView();
+          } else if (to === "top") {
+            window.scrollTo(0, 0);
+          } else if (to === "bottom") {
+            window.scrollTo(0, root.scrollHeight);
+          } else if (by !== null) {
+            window.scrollBy(0, by);
+          }
+          return null;
+        },
+      });
+
+      if (res[0].result) {
+        throw new Error(res[0].result);
+      }
+      return;
+    }
+    case "tab.sendKeys": {
+      let { tabId } = payload;
+      const { keys, modifiers = [], selector } = payload;
+      if (tabId === undefined) {
+        tabId = await getActiveTabId();
+      }
+
+      const res = await chrome.scripting.executeScript({
+        target: { tabId },
+        args: [keys, modifiers, selector ?? null],
+        func: (
+          keys: { key?: string; text?: string }[],
+          modifiers: string[],
+          selector: string | null,
+        ) => {
+          if (selector !== null) {
+            const element = document.querySelector(selector);
+            if (element === null) {
+              return `No element matches ${selector}`;
+            }
+            (element as HTMLElement).focus();
+          }
+
+          const init = {
+            bubbles: true,
+            cancelable: true,
+            ctrlKey: modifiers.includes("ctrl"),
+            altKey: modifiers.includes("alt"),
+            shiftKey: modifiers.includes("shift"),
+            metaKey: modifiers.includes("meta"),
+          };
+          const press = (key: string) => {
+            const target = document.activeElement ?? document.body;
+            const down = target.dispatchEvent(
+              new KeyboardEvent("keydown", { ...init, key }),
+            );
+            target.dispatchEvent(new KeyboardEvent("keyup", { ...init, key }));
+            return down ? target : null;
+          };
+
+          for (const { key, text } of keys) {
+            if (text !== undefined) {
+              // synthetic key events do not edit the page, insert the text once they were dispatched
+              for (const char of text) {
+                if (press(char) !== null) {
+                  document.execCommand("insertText", false, char);
+                }
+              }
+              continue;
+            }
+
+            const target = press(key!);
+            if (target === null) {
+              continue;
+            }
+            if (key === "Enter" && target instanceof HTMLInputElement) {
+              target.form?.requestSubmit();
+            } else if (key === "Enter" || key === " ") {
+              const text = key === "Enter" ? "\n" : " ";
+              document.execCommand("insertText", false, text);
+            } else if (key === "Backspace") {
+              document.execCommand("delete");
+            } else if (key === "Delete") {
+              document.execCommand("forwardDelete");
+            }
+          }
+          return null;
+        },
+      });
+
+      if (res[0].result) {
+        throw new Error(res[0].result);
+      }
+      return;
+    }
+    case "tab.find": {
+      let { tabId } = payload;
+      const { query, caseSensitive = false, highlight = false } = payload;
+      if (tabId === undefined) {
+        tabId = await getActiveTabId();
+      }
+
+      // firefox only, chromium browsers search the page text instead
+      if (browser.find !== undefined) {
+        const { count } = await browser.find.find(query, {
+          tabId,
+          caseSensitive,
+        });
+        if (highlight && count > 0) {
+          await browser.find.highlightResults({ tabId });
+        }
+        return count;
+      }
+
+      const res = await chrome.scripting.executeScript({
+        target: { tabId },
+        args: [query, caseSensitive, highlight],
+        func: (query: string, caseSensitive: boolean, highlight: boolean) => {
+          let text = document.body.innerText;
+          let needle = query;
+          if (!caseSensitive) {
+            text = text.toLowerCase();
+            needle = needle.toLowerCase();
+          }
+
+          let count = 0;
+          for (
+            let i = text.indexOf(needle);
+            i !== -1;
+            i = text.indexOf(needle, i + needle.length)
+          ) {
+            count++;
+          }
+
+          if (highlight && count > 0) {
+            // selects the first match, like the find bar of the browser
+            (window as any).find(query, caseSensitive);
+          }
+
+          return count;
+        },
+      });
+
+      return res[0].result;
+    }
+    case "tab.group.list": {
+      return await chrome.tabGroups.query(payload.query ?? {});
+    }
+    case "tab.text": {
+      let { tabId } = payload;
+      if (tabId === undefined) {
+        tabId = await getActiveTabId();
+      }
+
+      const res = await chrome.scripting.executeScript({
+        target: { tabId },
+        func: () => {
+          return document.body.innerText;
+        },
+      });
+
+      return res[0].result;
+    }
+    case "tab.resources": {
+      let { tabId } = payload;
+      if (tabId === undefined) {
+        tabId = await getActiveTabId();
+      }
+
+      const res = await chrome.scripting.executeScript({
+        target: { tabId },
+        func: () => {
+          return performance
+            .getEntriesByType("resource")
+            .map((entry) => {
+              const resource = entry as PerformanceResourceTiming;
+              return {
+                name: resource.name,
+                initiatorType: resource.initiatorType,
+                transferSize: resource.transferSize,
+                duration: resource.duration,
+              };
+            });
+        },
+      });
+
+      return res[0].result;
+    }
+    case "tab.group.create": {
+      const { tabIds, title, color } = payload;
+      const groupId = await chrome.tabs.group({ tabIds });
+      return await chrome.tabGroups.update(groupId, { title, color });
+    }
+    case "tab.group.add": {
+      const { tabIds, groupId } = payload;
+      return await chrome.tabs.group({ tabIds, groupId });
+    }
+    case "tab.group.update": {
+      const { groupId, title, color, collapsed } = payload;
+      return await chrome.tabGroups.update(groupId, {
+        title,
+        color,
+        collapsed,
+      });
+    }
+    case "tab.group.move": {
+      const { groupId, index, newWindow } = payload;
+      let { windowId } = payload;
+
+      // tabGroups.move needs an existing window, open one and drop its blank tab
+      let placeholderId: number | undefined;
+      if (newWindow) {
+        const window = await browser.windows.create({});
+        windowId = window.id;
+        placeholderId = window.tabs?.[0]?.id;
+      }
+
+      const group = await chrome.tabGroups.move(groupId, { windowId, index });
+      if (placeholderId !== undefined) {
+        await browser.tabs.remove(placeholderId);
+      }
+
+      return group;
+    }
     case "selection.get": {
       let { tabId } = payload;
       if (tabId === undefined) {
@@ -196,7 +774,7 @@ async function handleMessage(payload: any): Promise<any> {
       return;
     }
     case "window.list": {
-      return browser.windows.getAll({});
+      return browser.windows.getAll({ populate: true });
     }
     case "window.focus": {
       const { windowId } = payload;
@@ -204,18 +782,53 @@ async function handleMessage(payload: any): Promise<any> {
         focused: true,
       });
     }
+    case "window.update": {
+      const { windowId, state, left, top, width, height } = payload;
+      return await browser.windows.update(windowId, {
+        state,
+        left,
+        top,
+        width,
+        height,
+      });
+    }
     case "window.remove": {
       const { windowId } = payload;
       await browser.windows.remove(windowId);
       return;
     }
     case "window.create": {
-      const { url } = payload;
-      return await browser.windows.create({ url });
+      const { url, incognito, tabId } = payload;
+      if (incognito) {
+        await checkIncognitoAccess();
+      }
+
+      return await browser.windows.create({ url, incognito, tabId });
+    }
+    case "container.list": {
+      if (browser.contextualIdentities === undefined) {
+        throw new Error("Containers are not supported by this browser");
+      }
+
+      return await browser.contextualIdentities.query({});
+    }
+    case "ping": {
+      return {
+        browser: detectBrowser(),
+        extensionVersion: browser.runtime.getManifest().version,
+      };
     }
     case "extension.list": {
       return await browser.management.getAll();
     }
+    case "session.getRecentlyClosed": {
+      const { maxResults } = payload;
+      return await browser.sessions.getRecentlyClosed({ maxResults });
+    }
+    case "session.restore": {
+      const { sessionId } = payload;
+      return await browser.sessions.restore(sessionId);
+    }
     case "bookmark.list": {
       return await browser.bookmarks.getTree();
     }
@@ -227,16 +840,52 @@ async function handleMessage(payload: any): Promise<any> {
         url,
       });
     }
+    case "bookmark.get": {
+      const { id } = payload;
+      return await browser.bookmarks.get(id);
+    }
+    case "bookmark.move": {
+      const { id, parentId, index } = payload;
+      return await browser.bookmarks.move(id, { parentId, index });
+    }
     case "bookmark.remove": {
       const { id } = payload;
-      browser.bookmarks.remove(id);
+      await browser.bookmarks.remove(id);
       return;
     }
+    case "bookmark.search": {
+      const { query } = payload;
+      return await browser.bookmarks.search(query);
+    }
+    case "cookie.getAll": {
+      const { domain, url } = payload;
+      return await browser.cookies.getAll({ domain, url });
+    }
+    case "cookie.set": {
+      const { details } = payload;
+      return await browser.cookies.set(details);
+    }
+    case "cookie.remove": {
+      const { details } = payload;
+      return await browser.cookies.remove(details);
+    }
     case "download.list": {
       return await browser.downloads.search({});
     }
+    case "download.get": {
+      const { id } = payload;
+      return await browser.downloads.search({ id });
+    }
+    case "download.start": {
+      const { url, filename } = payload;
+      return await browser.downloads.download({ url, filename });
+    }
     case "history.search": {
-      return browser.history.search({ text: payload.query });
+      const { query, startTime, maxResults } = payload;
+      return browser.history.search({ text: query, startTime, maxResults });
+    }
+    case "history.getVisits": {
+      return await browser.history.getVisits({ url: payload.url });
     }
     default: {
       throw new Error(`Unknown command: ${payload.command}`);
@@ -244,6 +893,22 @@ async function handleMessage(payload: any): Promise<any> {
   }
 }
 
+function detectBrowser() {
+  const { userAgent } = navigator;
+  for (const [name, token] of [
+    ["Firefox", "Firefox"],
+    ["Edge", "Edg"],
+    ["Chrome", "Chrome"],
+  ]) {
+    const match = userAgent.match(new RegExp(`${token}/([\\d.]+)`));
+    if (match) {
+      return `${name} ${match[1]}`;
+    }
+  }
+
+  return userAgent;
+}
+
 async function getActiveTabId() {
   const activeTabs = await browser.tabs.query({
     active: true,
@@ -255,3 +920,11 @@ async function getActiveTabId() {
   }
   return tabId;
 }
+
+async function checkIncognitoAccess() {
+  if (!(await browser.extension.isAllowedIncognitoAccess())) {
+    throw new Error(
+      "The extension is not allowed in incognito, enable 'Allow in Incognito' in its settings"
+    );
+  }
+}
@@ -50,6 +50,17 @@ const lightTheme = {
   cursor: "#4d4d4c",
 };
 
+// webtermPort returns the port of the native host of the browser, it must match `webterm --browser`
+function webtermPort(userAgent: string) {
+  if (userAgent.includes("Firefox/")) {
+    return 9997;
+  }
+  if (userAgent.includes("Edg/")) {
+    return 9998;
+  }
+  return 9999;
+}
+
 async function main() {
   // wake up background script
   await chrome.runtime.sendMessage({ type: "popup" });
@@ -76,11 +87,14 @@ async function main() {
   terminal.open(document.getElementById("terminal")!);
   fitAddon.fit();
 
+  // each browser starts its own native host, on its own port
+  const port = webtermPort(navigator.userAgent);
+
   // check if webterm server is running
   let ready = false;
   while (!ready) {
     try {
-      const res = await fetch("http://localhost:9999/ready");
+      const res = await fetch(`http://localhost:${port}/ready`);
       if (res.status !== 200) {
         throw new Error("not ready");
       }
@@ -91,7 +105,7 @@ async function main() {
   }
 
   const ws = new WebSocket(
-    `ws://localhost:9999/pty?cols=${terminal.cols}&rows=${terminal.rows}`
+    `ws://localhost:${port}/pty?cols=${terminal.cols}&rows=${terminal.rows}`
   );
 
   ws.onclose = () => {
@@ -6,6 +6,8 @@ export const manifest: chrome.runtime.ManifestV3 = {
   name: pkg.displayName ?? pkg.name,
   version: pkg.version,
   manifest_version: 3,
+  // firefox only, the id allowed by the native messaging manifest written by `webterm init --browser firefox`
+  ...{ browser_specific_settings: { gecko: { id: "webterm@pomdtr.me" } } },
   action: {
     default_icon: {
       48: "icons/48.png",
@@ -18,11 +20,18 @@ export const manifest: chrome.runtime.ManifestV3 = {
   permissions: [
     "nativeMessaging",
     "tabs",
+    "tabGroups",
     "history",
     "bookmarks",
+    "cookies",
     "downloads",
     "management",
     "scripting",
+    "debugger",
+    "sessions",
+    // firefox only, ignored by chromium browsers
+    "contextualIdentities" as chrome.runtime.ManifestPermissions,
+    "find" as chrome.runtime.ManifestPermissions,
   ],
   host_permissions: ["*://*/*"],
   icons: {
@@ -30,6 +30,6 @@ func main() {
 	log.Default().SetOutput(f)
 
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
@@ -3,13 +3,18 @@ package server
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
+	"runtime/debug"
+	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/google/uuid"
@@ -18,8 +23,6 @@ import (
 // nativeEndian used to detect native byte order
 var nativeEndian binary.ByteOrder
 
-var webTermPort = 9999
-
 func init() {
 	// determine native byte order so that we can read message size correctly
 	var one int16 = 1
@@ -32,9 +35,37 @@ func init() {
 }
 
 type ExtensionMessage struct {
-	ID      string `json:"id"`
-	Payload any    `json:"payload"`
-	Error   string `json:"error,omitempty"`
+	ID        string `json:"id"`
+	RequestID string `json:"requestId,omitempty"`
+	Payload   any    `json:"payload"`
+	Error     string `json:"error,omitempty"`
+	// Event is set on the messages the extension sends on its own, while events are watched.
+	Event json.RawMessage `json:"event,omitempty"`
+}
+
+// RequestIDHeader carries the id used to correlate a cli request with its response.
+const RequestIDHeader = "X-Webterm-Request-Id"
+
+// VersionHeader carries the version of the native host on /ready responses.
+const VersionHeader = "X-Webterm-Version"
+
+// ProtocolHeader carries the version of the protocol spoken by the native host on /ready responses.
+const ProtocolHeader = "X-Webterm-Protocol"
+
+// ProtocolVersion is bumped when the messages exchanged with the cli or the extension change incompatibly.
+const ProtocolVersion = "1"
+
+// errExtensionGone is returned when the browser closed the native messaging pipe.
+var errExtensionGone = errors.New("the extension closed the native messaging connection")
+
+// Version returns the module version the host was built from.
+func Version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Version == "" {
+		return "(devel)"
+	}
+
+	return info.Main.Version
 }
 
 // readMessageLength reads and returns the message length value in native byte order.
@@ -48,13 +79,22 @@ func readMessageLength(msg []byte) (int, error) {
 	return int(length), nil
 }
 
-func NewServer(m *MessageHandler, environ []string) *http.Server {
+// NewServer returns the server relaying the cli requests to the extension, listening on the given port.
+func NewServer(m *MessageHandler, environ []string, port int) *http.Server {
 	http.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(VersionHeader, Version())
+		w.Header().Set(ProtocolHeader, ProtocolVersion)
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
 	})
 
 	http.HandleFunc("/browser", func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
 		if r.Method != http.MethodPost {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			w.Write([]byte("Method not allowed"))
@@ -68,8 +108,12 @@ func NewServer(m *MessageHandler, environ []string) *http.Server {
 			return
 		}
 
-		msg, err := m.send(payload)
-		if err != nil {
+		msg, err := m.send(r.Context(), requestID, payload)
+		if errors.Is(err, errExtensionGone) {
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(err.Error()))
+			return
+		} else if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(err.Error()))
 			return
@@ -84,6 +128,42 @@ func NewServer(m *MessageHandler, environ []string) *http.Server {
 		}
 	})
 
+	http.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		events, err := m.watch(r.Context())
+		if errors.Is(err, errExtensionGone) {
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(err.Error()))
+			return
+		} else if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer m.unwatch(events)
+
+		// events are streamed as json lines until the client goes away
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		flusher, _ := w.(http.Flusher)
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		for {
+			select {
+			case event := <-events:
+				if _, err := w.Write(append(event, '\n')); err != nil {
+					return
+				}
+				if flusher != nil {
+					flusher.Flush()
+				}
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+
 	var command string
 	var args []string
 	if shell, ok := os.LookupEnv("SHELL"); ok {
@@ -105,34 +185,114 @@ func NewServer(m *MessageHandler, environ []string) *http.Server {
 		Dir:     dir,
 	}))
 
-	log.Printf("Listening on port %d\n", webTermPort)
+	log.Printf("Listening on port %d\n", port)
 
 	return &http.Server{
-		Addr: fmt.Sprintf(":%d", webTermPort),
+		Addr: fmt.Sprintf(":%d", port),
 	}
 }
 
 type Message struct {
-	content any
-	err     error
+	requestID string
+	content   any
+	err       error
 }
 
 type MessageHandler struct {
-	subscriptions map[string]chan Message
+	// subscriptions maps the id of a message sent to the extension to the channel waiting for its answer
+	subscriptionsMu sync.Mutex
+	subscriptions   map[string]chan Message
+
+	// writeMu is held while writing a message to the extension, so that concurrent messages do not interleave
+	writeMu sync.Mutex
+
+	// watchers receive the events of the extension, it only sends them while there is at least one.
+	// subscribeMu is held while subscribing or unsubscribing, watchersMu guards the map
+	subscribeMu sync.Mutex
+	watchersMu  sync.Mutex
+	watchers    map[chan json.RawMessage]bool
 }
 
 func NewMessageHandler() *MessageHandler {
 	return &MessageHandler{
 		subscriptions: make(map[string]chan Message),
+		watchers:      make(map[chan json.RawMessage]bool),
 	}
 }
 
-func (h *MessageHandler) send(payload any) (any, error) {
+// watch registers a watcher of the extension events, asking the extension to send them for the first one.
+func (h *MessageHandler) watch(ctx context.Context) (chan json.RawMessage, error) {
+	h.subscribeMu.Lock()
+	defer h.subscribeMu.Unlock()
+
+	if h.watcherCount() == 0 {
+		if _, err := h.send(ctx, uuid.New().String(), map[string]any{
+			"command": "events.subscribe",
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	// buffered so that a burst of events does not block the loop, events are dropped when a watcher lags behind
+	c := make(chan json.RawMessage, 64)
+	h.watchersMu.Lock()
+	h.watchers[c] = true
+	h.watchersMu.Unlock()
+
+	return c, nil
+}
+
+// unwatch removes a watcher, asking the extension to stop sending events after the last one.
+func (h *MessageHandler) unwatch(c chan json.RawMessage) {
+	h.subscribeMu.Lock()
+	defer h.subscribeMu.Unlock()
+
+	h.watchersMu.Lock()
+	delete(h.watchers, c)
+	h.watchersMu.Unlock()
+
+	if h.watcherCount() > 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := h.send(ctx, uuid.New().String(), map[string]any{
+		"command": "events.unsubscribe",
+	}); err != nil {
+		log.Printf("Unable to unsubscribe from the extension events: %v", err)
+	}
+}
+
+func (h *MessageHandler) watcherCount() int {
+	h.watchersMu.Lock()
+	defer h.watchersMu.Unlock()
+
+	return len(h.watchers)
+}
+
+// broadcast forwards an event to every watcher.
+func (h *MessageHandler) broadcast(event json.RawMessage) {
+	h.watchersMu.Lock()
+	defer h.watchersMu.Unlock()
+
+	for c := range h.watchers {
+		select {
+		case c <- event:
+		default:
+			log.Printf("Dropped an event for a watcher lagging behind")
+		}
+	}
+}
+
+// send forwards a payload to the extension and waits for its answer, until ctx is done.
+func (h *MessageHandler) send(ctx context.Context, requestID string, payload any) (any, error) {
 	msgID := uuid.New().String()
 
 	msg := ExtensionMessage{
-		ID:      msgID,
-		Payload: payload,
+		ID:        msgID,
+		RequestID: requestID,
+		Payload:   payload,
 	}
 
 	byteMsg, err := json.Marshal(msg)
@@ -140,25 +300,34 @@ func (h *MessageHandler) send(payload any) (any, error) {
 		return nil, fmt.Errorf("unable to marshal OutgoingMessage struct to slice of bytes: %w", err)
 	}
 
+	// buffered so that a late answer does not block the loop once the request is gone
+	c := make(chan Message, 1)
+	h.subscriptionsMu.Lock()
+	h.subscriptions[msgID] = c
+	h.subscriptionsMu.Unlock()
+	// also runs when ctx is done before the answer, while the loop may be looking the message up
+	defer func() {
+		h.subscriptionsMu.Lock()
+		delete(h.subscriptions, msgID)
+		h.subscriptionsMu.Unlock()
+	}()
+
 	log.Printf("Sending message: %s", string(byteMsg))
-	if err := binary.Write(os.Stdout, nativeEndian, uint32(len(byteMsg))); err != nil {
-		return nil, fmt.Errorf("unable to write message length to Stdout: %v", err)
+	if err := h.writeMessage(byteMsg); err != nil {
+		return nil, err
 	}
 
-	var msgBuf bytes.Buffer
-	if _, err := msgBuf.Write(byteMsg); err != nil {
-		return nil, fmt.Errorf("unable to write message to buffer: %w", err)
+	var out Message
+	select {
+	case out = <-c:
+	case <-ctx.Done():
+		log.Printf("Request %s canceled before the extension answered", requestID)
+		return nil, ctx.Err()
 	}
-
-	c := make(chan Message)
-	h.subscriptions[msgID] = c
-	_, err = msgBuf.WriteTo(os.Stdout)
-	if err != nil {
-		return nil, fmt.Errorf("unable to write message buffer to Stdout: %w", err)
+	if out.requestID != requestID {
+		return nil, fmt.Errorf("extension answered request %s with request id %q", requestID, out.requestID)
 	}
 
-	out := <-c
-	delete(h.subscriptions, msgID)
 	if out.err != nil {
 		return nil, out.err
 	}
@@ -166,18 +335,34 @@ func (h *MessageHandler) send(payload any) (any, error) {
 	return out.content, nil
 }
 
-func (h *MessageHandler) Loop() {
-	for {
-		v := bufio.NewReader(os.Stdin)
-		// adjust buffer size to accommodate your json payload size limits; default is 4096
-		s := bufio.NewReader(v)
+// writeMessage writes a message to the extension, its length then its content, in a single write.
+func (h *MessageHandler) writeMessage(content []byte) error {
+	var msgBuf bytes.Buffer
+	if err := binary.Write(&msgBuf, nativeEndian, uint32(len(content))); err != nil {
+		return fmt.Errorf("unable to write message length to buffer: %w", err)
+	}
+	msgBuf.Write(content)
 
-		lengthBytes := make([]byte, 4)
+	h.writeMu.Lock()
+	defer h.writeMu.Unlock()
+
+	if _, err := msgBuf.WriteTo(os.Stdout); err != nil {
+		return fmt.Errorf("%w: unable to write message buffer to Stdout: %v", errExtensionGone, err)
+	}
 
+	return nil
+}
+
+func (h *MessageHandler) Loop() {
+	// a single reader for the whole stream, the messages sent in a burst are buffered together
+	s := bufio.NewReader(os.Stdin)
+	lengthBytes := make([]byte, 4)
+
+	for {
 		// we're going to indefinitely read the first 4 bytes in buffer, which gives us the message length.
 		// if stdIn is closed we'll exit the loop and shut down host
-		if _, err := s.Read(lengthBytes); err != nil {
-			if err == io.EOF {
+		if _, err := io.ReadFull(s, lengthBytes); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
 				log.Printf("Stdin closed; shutting down host")
 				os.Exit(0)
 			}
@@ -209,21 +394,31 @@ func (h *MessageHandler) Loop() {
 			continue
 		}
 
+		if msg.Event != nil {
+			h.broadcast(msg.Event)
+			continue
+		}
+
+		h.subscriptionsMu.Lock()
 		c, ok := h.subscriptions[msg.ID]
+		h.subscriptionsMu.Unlock()
 		if !ok {
 			log.Printf("No subscription found for message ID: %s", msg.ID)
 			continue
 		}
 
+		log.Printf("Received message %s for request %s", msg.ID, msg.RequestID)
 		if msg.Error != "" {
 			c <- Message{
-				err: fmt.Errorf(msg.Error),
+				requestID: msg.RequestID,
+				err:       fmt.Errorf(msg.Error),
 			}
 			continue
 		}
 
 		c <- Message{
-			content: msg.Payload,
+			requestID: msg.RequestID,
+			content:   msg.Payload,
 		}
 	}
 }
@@ -0,0 +1,39 @@
+package webterm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultBrowser is the browser targeted when none is chosen.
+const DefaultBrowser = "chrome"
+
+// browserPorts are the ports of the native hosts, each browser starts its own host so they can not share one.
+// The popup of the extension picks the same port from the browser it runs in.
+var browserPorts = map[string]int{
+	"chrome":  DefaultPort,
+	"edge":    9998,
+	"firefox": 9997,
+}
+
+// BrowserNames returns the names of the supported browsers, sorted.
+func BrowserNames() []string {
+	names := make([]string, 0, len(browserPorts))
+	for name := range browserPorts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// BrowserPort returns the port of the native host started by the given browser.
+func BrowserPort(browser string) (int, error) {
+	port, ok := browserPorts[browser]
+	if !ok {
+		return 0, fmt.Errorf("unknown browser: %s, expected one of %s", browser, strings.Join(BrowserNames(), ", "))
+	}
+
+	return port, nil
+}
@@ -0,0 +1,317 @@
+package webterm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultPort is the port the webterm server listens on.
+const DefaultPort = 9999
+
+// DefaultTimeout bounds how long a request waits for the browser to answer.
+const DefaultTimeout = 10 * time.Second
+
+// RequestIDHeader carries the id used to correlate a request with its response.
+const RequestIDHeader = "X-Webterm-Request-Id"
+
+// VersionHeader carries the version of the native host on its readiness endpoint.
+const VersionHeader = "X-Webterm-Version"
+
+// ProtocolHeader carries the protocol version of the native host on its readiness endpoint.
+const ProtocolHeader = "X-Webterm-Protocol"
+
+// DefaultRetries is how many times a request failing with a transient error is retried.
+const DefaultRetries = 2
+
+// retryDelay is the delay before the first retry, it doubles on each attempt.
+const retryDelay = 100 * time.Millisecond
+
+// Event describes a request sent to the server or the outcome of one, see Client.Trace.
+type Event struct {
+	Time      time.Time       `json:"time"`
+	Event     string          `json:"event"`
+	RequestID string          `json:"requestId"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	Status    int             `json:"status,omitempty"`
+	Bytes     int             `json:"bytes,omitempty"`
+	Duration  float64         `json:"durationMs,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	// Body is the raw response of a receive event, it is left out of the json encoding.
+	Body []byte `json:"-"`
+}
+
+// Transport sends a command to the extension and returns its raw json response, see Client.Transport.
+type Transport interface {
+	Send(ctx context.Context, payload any) ([]byte, error)
+}
+
+// Client sends commands to the browser extension through the webterm server.
+type Client struct {
+	// Port of the webterm server.
+	Port int
+	// HTTPClient sends the requests, its timeout applies to each of them.
+	HTTPClient *http.Client
+	// Trace is called, when set, for each request sent and each response or error received.
+	Trace func(Event)
+	// Retries is how many times a request failing with a transient error is sent again.
+	Retries int
+	// OnRetry is called, when set, before a request is sent again.
+	OnRetry func(attempt int, delay time.Duration, err error)
+	// Transport carries every command of the client when set, instead of the webterm server,
+	// e.g. a fake answering canned responses in tests.
+	Transport Transport
+}
+
+// NewClient returns a client for the server listening on the default port.
+func NewClient() *Client {
+	return &Client{
+		Port:       DefaultPort,
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+		Retries:    DefaultRetries,
+	}
+}
+
+func (c *Client) trace(event Event) {
+	if c.Trace == nil {
+		return
+	}
+
+	event.Time = time.Now()
+	c.Trace(event)
+}
+
+// Send sends a command to the extension and returns its raw json response.
+// The payload must contain a "command" key, along with the arguments of the command.
+// Requests failing with a transient error, such as the connection being reset while the browser wakes up,
+// are retried with an exponential backoff, errors returned by the extension are not. Once the request
+// was written only the commands which are safe to run twice, the read-only ones, are retried.
+func (c *Client) Send(ctx context.Context, payload any) ([]byte, error) {
+	if c.Transport != nil {
+		return c.Transport.Send(ctx, payload)
+	}
+
+	delay := retryDelay
+	for attempt := 1; ; attempt++ {
+		body, retry, err := c.send(ctx, payload)
+		if err == nil || !retry || attempt > c.Retries {
+			return body, err
+		}
+
+		if c.OnRetry != nil {
+			c.OnRetry(attempt, delay, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// isTransient reports whether a request failed in a way that may not happen again.
+func isTransient(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// idempotentCommands are the commands which only read the state of the browser, they can be sent again
+// when it is unknown whether the extension received them.
+var idempotentCommands = map[string]bool{
+	"ping":                      true,
+	"tab.list":                  true,
+	"tab.query":                 true,
+	"tab.get":                   true,
+	"tab.isError":               true,
+	"tab.getZoom":               true,
+	"tab.source":                true,
+	"tab.text":                  true,
+	"tab.resources":             true,
+	"tab.screenshot":            true,
+	"tab.group.list":            true,
+	"selection.get":             true,
+	"window.list":               true,
+	"container.list":            true,
+	"extension.list":            true,
+	"session.getRecentlyClosed": true,
+	"bookmark.list":             true,
+	"bookmark.get":              true,
+	"bookmark.search":           true,
+	"cookie.getAll":             true,
+	"download.list":             true,
+	"download.get":              true,
+	"history.search":            true,
+	"history.getVisits":         true,
+}
+
+// isIdempotent reports whether the command of an encoded payload is safe to send twice.
+func isIdempotent(payload []byte) bool {
+	var msg struct {
+		Command string `json:"command"`
+	}
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		return false
+	}
+
+	return idempotentCommands[msg.Command]
+}
+
+// send sends a single request, it reports whether it can be sent again after its error: the error
+// is transient, and either the request was not written or its command is idempotent.
+func (c *Client) send(ctx context.Context, payload any) ([]byte, bool, error) {
+	target := fmt.Sprintf("http://localhost:%d/browser", c.Port)
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// a request failing before it is written never reached the extension
+	var wrote atomic.Bool
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			wrote.Store(true)
+		},
+	})
+	retryable := func(err error) bool {
+		return isTransient(err) && (!wrote.Load() || isIdempotent(b))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(b))
+	if err != nil {
+		return nil, false, err
+	}
+
+	requestID := uuid.New().String()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(RequestIDHeader, requestID)
+
+	c.trace(Event{Event: "send", RequestID: requestID, Payload: b})
+	start := time.Now()
+	elapsed := func() float64 {
+		return float64(time.Since(start).Microseconds()) / 1000
+	}
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		retry := retryable(err)
+		if os.IsTimeout(err) && ctx.Err() == nil {
+			err = fmt.Errorf("%w after %s", ErrTimeout, c.HTTPClient.Timeout)
+		} else if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+			err = notConnected(err)
+		}
+		c.trace(Event{Event: "error", RequestID: requestID, Duration: elapsed(), Error: err.Error()})
+		return nil, retry, err
+	}
+	defer res.Body.Close()
+
+	if err := matchResponse(requestID, res); err != nil {
+		// something else than the native host is answering on the port
+		err = notConnected(err)
+		c.trace(Event{Event: "error", RequestID: requestID, Status: res.StatusCode, Duration: elapsed(), Error: err.Error()})
+		return nil, false, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(res.Body)
+		c.trace(Event{Event: "error", RequestID: requestID, Status: res.StatusCode, Duration: elapsed(), Error: string(msg)})
+		if res.StatusCode == http.StatusBadGateway {
+			return nil, false, notConnected(errors.New(string(msg)))
+		}
+		return nil, false, browserError(string(msg))
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, retryable(err), err
+	}
+
+	// some host implementations answer successfully with the error of the extension as the payload
+	if extErr, ok := decodeExtensionError(body); ok {
+		c.trace(Event{Event: "error", RequestID: requestID, Status: res.StatusCode, Duration: elapsed(), Error: extErr.Message})
+		return nil, false, extErr
+	}
+
+	c.trace(Event{Event: "receive", RequestID: requestID, Status: res.StatusCode, Bytes: len(body), Duration: elapsed(), Body: body})
+	return body, false, nil
+}
+
+// matchResponse checks that the response was issued for the given request.
+func matchResponse(requestID string, res *http.Response) error {
+	responseID := res.Header.Get(RequestIDHeader)
+	if responseID == "" {
+		return fmt.Errorf("response for request %s is missing a request id", requestID)
+	}
+
+	if responseID != requestID {
+		return fmt.Errorf("response request id %s does not match request %s", responseID, requestID)
+	}
+
+	return nil
+}
+
+// HostInfo describes the running native host and the extension it is connected to.
+type HostInfo struct {
+	HostVersion      string `json:"hostVersion"`
+	Protocol         string `json:"protocol"`
+	Browser          string `json:"browser"`
+	ExtensionVersion string `json:"extensionVersion"`
+}
+
+// Ping checks that the native host is running and that the extension answers.
+func (c *Client) Ping(ctx context.Context) (*HostInfo, error) {
+	var info HostInfo
+	// the readiness endpoint belongs to the webterm server, a custom transport only answers the commands
+	if c.Transport == nil {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://localhost:%d/ready", c.Port), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return nil, notConnected(err)
+		}
+		res.Body.Close()
+
+		info.HostVersion = res.Header.Get(VersionHeader)
+		info.Protocol = res.Header.Get(ProtocolHeader)
+	}
+
+	if err := c.sendJSON(ctx, map[string]any{
+		"command": "ping",
+	}, &info); err != nil {
+		return &info, err
+	}
+
+	return &info, nil
+}
+
+// sendJSON sends a command and decodes its response into v, when v is not nil.
+func (c *Client) sendJSON(ctx context.Context, payload any, v any) error {
+	res, err := c.Send(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(res, v); err != nil {
+		return &DecodeError{Err: err, Body: res}
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package webterm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeTransport answers each command with a canned json response and records the payloads sent.
+type fakeTransport struct {
+	// response is returned for every command, null when empty.
+	response string
+	err      error
+	sent     []map[string]any
+}
+
+func (f *fakeTransport) Send(ctx context.Context, payload any) ([]byte, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg map[string]any
+	if err := json.Unmarshal(b, &msg); err != nil {
+		return nil, err
+	}
+	f.sent = append(f.sent, msg)
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.response == "" {
+		return []byte("null"), nil
+	}
+
+	return []byte(f.response), nil
+}
+
+// assertSent checks that the single payload sent matches the given json object.
+func (f *fakeTransport) assertSent(t *testing.T, want string) {
+	t.Helper()
+
+	if len(f.sent) != 1 {
+		t.Fatalf("sent %d commands, want 1", len(f.sent))
+	}
+
+	var msg map[string]any
+	if err := json.Unmarshal([]byte(want), &msg); err != nil {
+		t.Fatalf("invalid expected payload %s: %v", want, err)
+	}
+
+	if !reflect.DeepEqual(f.sent[0], msg) {
+		got, _ := json.Marshal(f.sent[0])
+		t.Errorf("payload = %s, want %s", got, want)
+	}
+}
+
+// serverClient returns a client sending its requests to a test server calling handler.
+func serverClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient()
+	client.Port = port
+	return client
+}
+
+// echoRequestID answers a request with its own request id, as the native host does.
+func echoRequestID(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(RequestIDHeader, r.Header.Get(RequestIDHeader))
+}
+
+func TestMatchResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		responseID string
+		wantErr    bool
+	}{
+		{name: "matching id", responseID: "a1"},
+		{name: "mismatched id", responseID: "b2", wantErr: true},
+		{name: "missing id", responseID: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{}}
+			if tt.responseID != "" {
+				res.Header.Set(RequestIDHeader, tt.responseID)
+			}
+
+			err := matchResponse("a1", res)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("matchResponse() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSendRejectsForeignResponses(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "mismatched id",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set(RequestIDHeader, "another-request")
+				w.Write([]byte(`[]`))
+			},
+		},
+		{
+			name: "missing id",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`[]`))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			client := serverClient(t, func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				tt.handler(w, r)
+			})
+
+			_, err := client.Send(context.Background(), map[string]any{"command": "tab.list"})
+			if !errors.Is(err, ErrNotConnected) {
+				t.Errorf("got error %v, want ErrNotConnected", err)
+			}
+			if n := atomic.LoadInt32(&requests); n != 1 {
+				t.Errorf("sent %d requests, want 1", n)
+			}
+		})
+	}
+}
+
+// dropFirstRequests closes the connection of the first n requests without answering.
+func dropFirstRequests(t *testing.T, n int32, requests *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(requests, 1) <= n {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			conn.Close()
+			return
+		}
+
+		echoRequestID(w, r)
+		w.Write([]byte(`[{"id": 1}]`))
+	}
+}
+
+func TestSendRetriesTransientErrors(t *testing.T) {
+	var requests int32
+	client := serverClient(t, dropFirstRequests(t, 1, &requests))
+
+	var retries []int
+	client.OnRetry = func(attempt int, delay time.Duration, err error) {
+		retries = append(retries, attempt)
+	}
+
+	res, err := client.Send(context.Background(), map[string]any{"command": "tab.list"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(res) != `[{"id": 1}]` {
+		t.Errorf("got response %s", res)
+	}
+	if !reflect.DeepEqual(retries, []int{1}) {
+		t.Errorf("retried on attempts %v, want [1]", retries)
+	}
+}
+
+func TestSendDoesNotRetryWrittenActions(t *testing.T) {
+	var requests int32
+	client := serverClient(t, dropFirstRequests(t, 1, &requests))
+
+	if _, err := client.Send(context.Background(), map[string]any{"command": "tab.create", "urls": []string{"https://go.dev"}}); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	// the extension may have opened the tab already, sending it again could open a second one
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("sent %d requests, want 1", n)
+	}
+}
+
+func TestSendGivesUpAfterRetries(t *testing.T) {
+	var requests int32
+	client := serverClient(t, dropFirstRequests(t, 10, &requests))
+	client.Retries = 1
+
+	if _, err := client.Send(context.Background(), map[string]any{"command": "tab.list"}); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("sent %d requests, want 2", n)
+	}
+}
+
+func TestSendDoesNotRetryExtensionErrors(t *testing.T) {
+	var requests int32
+	client := serverClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		echoRequestID(w, r)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("No tab with id: 3"))
+	})
+
+	_, err := client.Send(context.Background(), map[string]any{"command": "tab.get", "tabId": 3})
+
+	var extErr *ExtensionError
+	if !errors.As(err, &extErr) {
+		t.Fatalf("got error %v, want an ExtensionError", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("sent %d requests, want 1", n)
+	}
+}
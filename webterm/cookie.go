@@ -0,0 +1,111 @@
+package webterm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type Cookie struct {
+	Domain         string  `json:"domain"`
+	ExpirationDate float64 `json:"expirationDate,omitempty"`
+	HostOnly       bool    `json:"hostOnly"`
+	HttpOnly       bool    `json:"httpOnly"`
+	Name           string  `json:"name"`
+	Path           string  `json:"path"`
+	SameSite       string  `json:"sameSite"`
+	Secure         bool    `json:"secure"`
+	Session        bool    `json:"session"`
+	StoreID        string  `json:"storeId"`
+	Value          string  `json:"value"`
+}
+
+// URL returns the url the browser requires to set or remove the cookie.
+func (c Cookie) URL() string {
+	scheme := "http"
+	if c.Secure {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, strings.TrimPrefix(c.Domain, "."), c.Path)
+}
+
+// CookieQuery filters the cookies returned by GetCookies, empty fields are ignored.
+type CookieQuery struct {
+	// URL only keeps the cookies sent to the url.
+	URL string
+	// Domain only keeps the cookies of the domain and its subdomains.
+	Domain string
+}
+
+// GetCookies lists the cookies matching the query, every cookie for an empty query.
+func (c *Client) GetCookies(ctx context.Context, query CookieQuery) ([]Cookie, error) {
+	msg := map[string]any{
+		"command": "cookie.getAll",
+	}
+
+	if query.URL != "" {
+		msg["url"] = query.URL
+	}
+	if query.Domain != "" {
+		msg["domain"] = query.Domain
+	}
+
+	var cookies []Cookie
+	if err := c.sendJSON(ctx, msg, &cookies); err != nil {
+		return nil, err
+	}
+
+	return cookies, nil
+}
+
+// SetCookie creates the cookie, replacing the one with the same name, url and store.
+// A cookie returned by GetCookies is recreated as is, host-only and session cookies included.
+func (c *Client) SetCookie(ctx context.Context, cookie Cookie) error {
+	details := map[string]any{
+		"url":      cookie.URL(),
+		"name":     cookie.Name,
+		"value":    cookie.Value,
+		"path":     cookie.Path,
+		"secure":   cookie.Secure,
+		"httpOnly": cookie.HttpOnly,
+		"sameSite": cookie.SameSite,
+	}
+
+	// omitting the domain is the only way to recreate a host-only cookie
+	if !cookie.HostOnly {
+		details["domain"] = cookie.Domain
+	}
+
+	if !cookie.Session {
+		details["expirationDate"] = cookie.ExpirationDate
+	}
+
+	if cookie.StoreID != "" {
+		details["storeId"] = cookie.StoreID
+	}
+
+	_, err := c.Send(ctx, map[string]any{
+		"command": "cookie.set",
+		"details": details,
+	})
+	return err
+}
+
+// RemoveCookie deletes the cookie from its store.
+func (c *Client) RemoveCookie(ctx context.Context, cookie Cookie) error {
+	details := map[string]any{
+		"url":  cookie.URL(),
+		"name": cookie.Name,
+	}
+
+	if cookie.StoreID != "" {
+		details["storeId"] = cookie.StoreID
+	}
+
+	_, err := c.Send(ctx, map[string]any{
+		"command": "cookie.remove",
+		"details": details,
+	})
+	return err
+}
@@ -0,0 +1,65 @@
+package webterm
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestCookieMethods(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		response string
+		call     func(c *Client) (any, error)
+		wantSent string
+		want     any
+	}{
+		{
+			name:     "GetCookies",
+			response: `[{"name": "sid", "value": "1", "domain": ".github.com", "path": "/"}]`,
+			call: func(c *Client) (any, error) {
+				return c.GetCookies(ctx, CookieQuery{Domain: "github.com"})
+			},
+			wantSent: `{"command": "cookie.getAll", "domain": "github.com"}`,
+			want:     []Cookie{{Name: "sid", Value: "1", Domain: ".github.com", Path: "/"}},
+		},
+		{
+			name: "SetCookie recreates a host-only session cookie",
+			call: func(c *Client) (any, error) {
+				return nil, c.SetCookie(ctx, Cookie{Name: "sid", Value: "1", Domain: "github.com", Path: "/", Secure: true, HostOnly: true, Session: true, SameSite: "lax"})
+			},
+			wantSent: `{"command": "cookie.set", "details": {"url": "https://github.com/", "name": "sid", "value": "1", "path": "/", "secure": true, "httpOnly": false, "sameSite": "lax"}}`,
+		},
+		{
+			name: "SetCookie keeps the domain and expiration",
+			call: func(c *Client) (any, error) {
+				return nil, c.SetCookie(ctx, Cookie{Name: "sid", Value: "1", Domain: ".github.com", Path: "/", ExpirationDate: 42, StoreID: "1"})
+			},
+			wantSent: `{"command": "cookie.set", "details": {"url": "http://github.com/", "name": "sid", "value": "1", "path": "/", "secure": false, "httpOnly": false, "sameSite": "", "domain": ".github.com", "expirationDate": 42, "storeId": "1"}}`,
+		},
+		{
+			name: "RemoveCookie",
+			call: func(c *Client) (any, error) {
+				return nil, c.RemoveCookie(ctx, Cookie{Name: "sid", Domain: ".github.com", Path: "/", Secure: true})
+			},
+			wantSent: `{"command": "cookie.remove", "details": {"url": "https://github.com/", "name": "sid"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeTransport{response: tt.response}
+			got, err := tt.call(&Client{Transport: transport})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			transport.assertSent(t, tt.wantSent)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
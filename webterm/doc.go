@@ -0,0 +1,17 @@
+// Package webterm controls the browser through the webterm server, the same way the webterm cli does.
+//
+// The server must be running, it is started by the browser extension through native messaging.
+//
+//	client := webterm.NewClient()
+//	tabs, err := client.ListTabs(context.Background())
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	for _, tab := range tabs {
+//		fmt.Println(tab.ID, tab.Title, tab.URL)
+//	}
+//
+// Commands without a dedicated method can be sent with Client.Send, the response is the raw json
+// returned by the extension.
+package webterm
@@ -0,0 +1,111 @@
+package webterm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ErrNotConnected is returned when the native host or the extension can not be reached.
+var ErrNotConnected = errors.New("the browser is not connected")
+
+// ErrNotFound is matched by the errors reporting that a tab, window or group does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrTimeout is returned when the browser does not answer in time.
+var ErrTimeout = errors.New("timed out waiting for browser response")
+
+// notConnected explains how to fix a connection failure.
+func notConnected(err error) error {
+	return fmt.Errorf("%w (%v), check that the webterm extension is installed and enabled, and that the native messaging manifest is registered with `webterm init`", ErrNotConnected, err)
+}
+
+type notFoundError struct {
+	msg string
+}
+
+func (e *notFoundError) Error() string {
+	return e.msg
+}
+
+func (e *notFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// NotFoundf formats an error matching ErrNotFound.
+func NotFoundf(format string, a ...any) error {
+	return &notFoundError{msg: fmt.Sprintf(format, a...)}
+}
+
+// notFoundPattern matches the errors of the browser apis for missing tabs, windows and groups.
+var notFoundPattern = regexp.MustCompile(`(?i)^no \w+( \w+)? with id`)
+
+// ExtensionError is an error reported by the extension while running a command.
+type ExtensionError struct {
+	Message string `json:"error"`
+	// Code is an optional machine readable kind of error, e.g. not_found.
+	Code string `json:"code,omitempty"`
+}
+
+func (e *ExtensionError) Error() string {
+	return e.Message
+}
+
+// Is matches ErrNotFound for the errors reporting a missing tab, window or group.
+func (e *ExtensionError) Is(target error) bool {
+	return target == ErrNotFound && (e.Code == "not_found" || notFoundPattern.MatchString(e.Message))
+}
+
+// browserError converts an error message of the extension.
+func browserError(msg string) error {
+	return &ExtensionError{Message: msg}
+}
+
+// decodeExtensionError recognizes a response holding an error instead of a result,
+// an object with a non-empty error message and optionally a code, but no other key.
+func decodeExtensionError(body []byte) (*ExtensionError, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, false
+	}
+
+	if _, ok := fields["error"]; !ok {
+		return nil, false
+	}
+	for key := range fields {
+		if key != "error" && key != "code" {
+			return nil, false
+		}
+	}
+
+	var extErr ExtensionError
+	if err := json.Unmarshal(body, &extErr); err != nil || extErr.Message == "" {
+		return nil, false
+	}
+
+	return &extErr, true
+}
+
+// DecodeErrorExcerpt is the number of bytes of the response quoted by a DecodeError, 0 to quote all of it.
+var DecodeErrorExcerpt = 200
+
+// DecodeError is returned when a response of the extension does not have the expected shape,
+// e.g. after a browser update changed the type of a field.
+type DecodeError struct {
+	Err  error
+	Body []byte
+}
+
+func (e *DecodeError) Error() string {
+	body := string(e.Body)
+	if DecodeErrorExcerpt > 0 && len(e.Body) > DecodeErrorExcerpt {
+		body = fmt.Sprintf("%s... (%d more bytes, use --verbose to see all of it)", e.Body[:DecodeErrorExcerpt], len(e.Body)-DecodeErrorExcerpt)
+	}
+
+	return fmt.Sprintf("unexpected response from the browser: %v, got: %s", e.Err, body)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,118 @@
+package webterm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestDecodeExtensionError(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		wantOK       bool
+		wantMessage  string
+		wantNotFound bool
+	}{
+		{
+			name:        "error message",
+			body:        `{"error": "Cannot access a chrome:// URL"}`,
+			wantOK:      true,
+			wantMessage: "Cannot access a chrome:// URL",
+		},
+		{
+			name:         "error message with a code",
+			body:         `{"error": "gone", "code": "not_found"}`,
+			wantOK:       true,
+			wantMessage:  "gone",
+			wantNotFound: true,
+		},
+		{
+			name:         "not found message",
+			body:         `{"error": "No tab with id: 3."}`,
+			wantOK:       true,
+			wantMessage:  "No tab with id: 3.",
+			wantNotFound: true,
+		},
+		{
+			name: "tab with an error field",
+			body: `{"id": 3, "url": "https://example.com", "error": "net::ERR_NAME_NOT_RESOLVED"}`,
+		},
+		{
+			name: "bare string",
+			body: `"No tab with id: 3."`,
+		},
+		{
+			name: "empty error message",
+			body: `{"error": ""}`,
+		},
+		{
+			name: "error object",
+			body: `{"error": {"message": "failed"}}`,
+		},
+		{
+			name: "list of tabs",
+			body: `[{"error": "failed"}]`,
+		},
+		{
+			name: "null",
+			body: `null`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extErr, ok := decodeExtensionError([]byte(tt.body))
+			if ok != tt.wantOK {
+				t.Fatalf("decodeExtensionError() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+
+			if extErr.Message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", extErr.Message, tt.wantMessage)
+			}
+			if errors.Is(extErr, ErrNotFound) != tt.wantNotFound {
+				t.Errorf("errors.Is(err, ErrNotFound) = %v, want %v", !tt.wantNotFound, tt.wantNotFound)
+			}
+		})
+	}
+}
+
+func TestSendExtensionErrorPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{name: "error payload", body: `{"error": "No tab with id: 3."}`, wantErr: true},
+		{name: "tab with an error field", body: `{"id": 3, "error": "net::ERR_FAILED"}`},
+		{name: "bare string", body: `"No tab with id: 3."`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := serverClient(t, func(w http.ResponseWriter, r *http.Request) {
+				echoRequestID(w, r)
+				w.Write([]byte(tt.body))
+			})
+
+			res, err := client.Send(context.Background(), map[string]any{"command": "tab.get"})
+			if tt.wantErr {
+				if !errors.Is(err, ErrNotFound) {
+					t.Errorf("got error %v, want ErrNotFound", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(res) != tt.body {
+				t.Errorf("got %s, want %s", res, tt.body)
+			}
+		})
+	}
+}
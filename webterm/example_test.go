@@ -0,0 +1,111 @@
+package webterm_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/pomdtr/webterm/webterm"
+)
+
+func ExampleClient_ListTabs() {
+	client := webterm.NewClient()
+
+	tabs, err := client.ListTabs(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, tab := range tabs {
+		fmt.Println(tab.ID, tab.Title, tab.URL)
+	}
+}
+
+func ExampleClient_CreateTabs() {
+	client := webterm.NewClient()
+
+	tabs, err := client.CreateTabs(context.Background(), []string{"https://go.dev", "https://pkg.go.dev"}, &webterm.CreateTabsOptions{
+		Inactive: true,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, tab := range tabs {
+		fmt.Println("opened tab", tab.ID)
+	}
+}
+
+func ExampleClient_CloseTabs() {
+	client := webterm.NewClient()
+	ctx := context.Background()
+
+	tabs, err := client.QueryTabs(ctx, webterm.TabQuery{URL: []string{"*://*.example.com/*"}})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tabIds := make([]int, len(tabs))
+	for i, tab := range tabs {
+		tabIds[i] = tab.ID
+	}
+
+	if _, err := client.CloseTabs(ctx, tabIds...); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func ExampleClient_CreateTabGroup() {
+	client := webterm.NewClient()
+	ctx := context.Background()
+
+	tab, err := client.GetActiveTab(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	group, err := client.CreateTabGroup(ctx, []int{tab.ID}, &webterm.CreateTabGroupOptions{
+		Title: "reading",
+		Color: "blue",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("created group", group.ID)
+}
+
+func ExampleClient_Send() {
+	client := webterm.NewClient()
+
+	// commands without a dedicated method return the raw json of the extension
+	res, err := client.Send(context.Background(), map[string]any{
+		"command": "tab.source",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(string(res))
+}
+
+// browser answers every command with the same tabs, as a Transport standing in for the browser.
+type browser struct{}
+
+func (browser) Send(ctx context.Context, payload any) ([]byte, error) {
+	return []byte(`[{"id": 1, "title": "The Go Programming Language", "url": "https://go.dev"}]`), nil
+}
+
+func ExampleTransport() {
+	client := &webterm.Client{Transport: browser{}}
+
+	tabs, err := client.ListTabs(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, tab := range tabs {
+		fmt.Println(tab.ID, tab.Title)
+	}
+	// Output: 1 The Go Programming Language
+}
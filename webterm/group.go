@@ -0,0 +1,152 @@
+package webterm
+
+import (
+	"context"
+)
+
+type TabGroup struct {
+	Collapsed bool   `json:"collapsed"`
+	Color     string `json:"color"`
+	ID        int    `json:"id"`
+	Title     string `json:"title"`
+	WindowID  int    `json:"windowId"`
+}
+
+// TabGroupNone is the group id reported for tabs that are not part of a group.
+const TabGroupNone = -1
+
+// ListTabGroups lists the tab groups of every window.
+func (c *Client) ListTabGroups(ctx context.Context) ([]TabGroup, error) {
+	var groups []TabGroup
+	if err := c.sendJSON(ctx, map[string]any{
+		"command": "tab.group.list",
+	}, &groups); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// GetTabGroup returns the tab group with the given id.
+func (c *Client) GetTabGroup(ctx context.Context, groupId int) (*TabGroup, error) {
+	groups, err := c.ListTabGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, group := range groups {
+		if group.ID == groupId {
+			return &groups[i], nil
+		}
+	}
+
+	return nil, NotFoundf("no group with id: %d", groupId)
+}
+
+// CreateTabGroupOptions are the optional settings of CreateTabGroup.
+type CreateTabGroupOptions struct {
+	Title string
+	// Color is one of the colors of the browser palette, e.g. blue, the browser picks one by default.
+	Color string
+}
+
+// CreateTabGroup groups the given tabs in a new group and returns it.
+func (c *Client) CreateTabGroup(ctx context.Context, tabIds []int, opts *CreateTabGroupOptions) (*TabGroup, error) {
+	msg := map[string]any{
+		"command": "tab.group.create",
+		"tabIds":  tabIds,
+	}
+
+	if opts != nil {
+		if opts.Title != "" {
+			msg["title"] = opts.Title
+		}
+		if opts.Color != "" {
+			msg["color"] = opts.Color
+		}
+	}
+
+	var group TabGroup
+	if err := c.sendJSON(ctx, msg, &group); err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// AddToTabGroup adds tabs to an existing group, moving them next to its other tabs.
+func (c *Client) AddToTabGroup(ctx context.Context, groupId int, tabIds ...int) error {
+	return c.sendJSON(ctx, map[string]any{
+		"command": "tab.group.add",
+		"groupId": groupId,
+		"tabIds":  tabIds,
+	}, nil)
+}
+
+// UngroupTabs removes tabs from their group, the browser removes a group once its last tab leaves it.
+func (c *Client) UngroupTabs(ctx context.Context, tabIds ...int) error {
+	return c.sendJSON(ctx, map[string]any{
+		"command": "tab.ungroup",
+		"tabIds":  tabIds,
+	}, nil)
+}
+
+// TabGroupUpdate are the group properties changed by UpdateTabGroup, nil fields are left as they are.
+type TabGroupUpdate struct {
+	Title     *string
+	Color     *string
+	Collapsed *bool
+}
+
+// UpdateTabGroup changes the title, color or collapsed state of a group.
+func (c *Client) UpdateTabGroup(ctx context.Context, groupId int, update TabGroupUpdate) error {
+	msg := map[string]any{
+		"command": "tab.group.update",
+		"groupId": groupId,
+	}
+
+	if update.Title != nil {
+		msg["title"] = *update.Title
+	}
+	if update.Color != nil {
+		msg["color"] = *update.Color
+	}
+	if update.Collapsed != nil {
+		msg["collapsed"] = *update.Collapsed
+	}
+
+	return c.sendJSON(ctx, msg, nil)
+}
+
+// MoveTabGroupOptions are the optional settings of MoveTabGroup.
+type MoveTabGroupOptions struct {
+	// WindowID moves the group to another window, it stays in its window by default.
+	WindowID int
+	// NewWindow moves the group to a new window.
+	NewWindow bool
+}
+
+// MoveTabGroup moves a group to a position of its window, -1 for the end, and returns the moved group.
+func (c *Client) MoveTabGroup(ctx context.Context, groupId int, index int, opts *MoveTabGroupOptions) (*TabGroup, error) {
+	msg := map[string]any{
+		"command": "tab.group.move",
+		"groupId": groupId,
+		"index":   index,
+	}
+
+	if opts != nil {
+		if opts.WindowID != 0 {
+			msg["windowId"] = opts.WindowID
+		}
+		if opts.NewWindow {
+			msg["newWindow"] = true
+		}
+	}
+
+	var group TabGroup
+	if err := c.sendJSON(ctx, msg, &group); err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
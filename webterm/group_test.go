@@ -0,0 +1,94 @@
+package webterm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGroupMethods(t *testing.T) {
+	ctx := context.Background()
+	title, collapsed := "docs", true
+
+	tests := []struct {
+		name     string
+		response string
+		call     func(c *Client) (any, error)
+		wantSent string
+		want     any
+	}{
+		{
+			name:     "ListTabGroups",
+			response: `[{"id": 5, "title": "docs", "color": "blue"}]`,
+			call: func(c *Client) (any, error) {
+				return c.ListTabGroups(ctx)
+			},
+			wantSent: `{"command": "tab.group.list"}`,
+			want:     []TabGroup{{ID: 5, Title: "docs", Color: "blue"}},
+		},
+		{
+			name:     "CreateTabGroup",
+			response: `{"id": 6, "title": "docs"}`,
+			call: func(c *Client) (any, error) {
+				return c.CreateTabGroup(ctx, []int{1, 2}, &CreateTabGroupOptions{Title: "docs"})
+			},
+			wantSent: `{"command": "tab.group.create", "tabIds": [1, 2], "title": "docs"}`,
+			want:     &TabGroup{ID: 6, Title: "docs"},
+		},
+		{
+			name: "AddToTabGroup",
+			call: func(c *Client) (any, error) {
+				return nil, c.AddToTabGroup(ctx, 6, 3)
+			},
+			wantSent: `{"command": "tab.group.add", "groupId": 6, "tabIds": [3]}`,
+		},
+		{
+			name: "UngroupTabs",
+			call: func(c *Client) (any, error) {
+				return nil, c.UngroupTabs(ctx, 3, 4)
+			},
+			wantSent: `{"command": "tab.ungroup", "tabIds": [3, 4]}`,
+		},
+		{
+			name: "UpdateTabGroup only sends the changed fields",
+			call: func(c *Client) (any, error) {
+				return nil, c.UpdateTabGroup(ctx, 6, TabGroupUpdate{Title: &title, Collapsed: &collapsed})
+			},
+			wantSent: `{"command": "tab.group.update", "groupId": 6, "title": "docs", "collapsed": true}`,
+		},
+		{
+			name:     "MoveTabGroup",
+			response: `{"id": 6, "windowId": 3}`,
+			call: func(c *Client) (any, error) {
+				return c.MoveTabGroup(ctx, 6, -1, &MoveTabGroupOptions{NewWindow: true})
+			},
+			wantSent: `{"command": "tab.group.move", "groupId": 6, "index": -1, "newWindow": true}`,
+			want:     &TabGroup{ID: 6, WindowID: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeTransport{response: tt.response}
+			got, err := tt.call(&Client{Transport: transport})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			transport.assertSent(t, tt.wantSent)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTabGroupNotFound(t *testing.T) {
+	transport := &fakeTransport{response: `[{"id": 5}]`}
+	client := &Client{Transport: transport}
+
+	if _, err := client.GetTabGroup(context.Background(), 6); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got error %v, want ErrNotFound", err)
+	}
+}
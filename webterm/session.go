@@ -0,0 +1,61 @@
+package webterm
+
+import (
+	"context"
+)
+
+// Session is a closed tab or window, only one of Tab or Window is set.
+type Session struct {
+	LastModified float64 `json:"lastModified"`
+	Tab          *Tab    `json:"tab,omitempty"`
+	Window       *Window `json:"window,omitempty"`
+}
+
+// SessionID returns the id to pass to RestoreSession.
+func (s Session) SessionID() string {
+	if s.Tab != nil {
+		return s.Tab.SessionID
+	}
+	if s.Window != nil {
+		return s.Window.SessionID
+	}
+
+	return ""
+}
+
+// RecentlyClosed lists the closed tabs and windows, most recent first.
+// The browser caps the list at 25 entries, limit can only lower it, 0 keeps the browser default.
+func (c *Client) RecentlyClosed(ctx context.Context, limit int) ([]Session, error) {
+	msg := map[string]any{
+		"command": "session.getRecentlyClosed",
+	}
+
+	if limit > 0 {
+		msg["maxResults"] = limit
+	}
+
+	var sessions []Session
+	if err := c.sendJSON(ctx, msg, &sessions); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// RestoreSession reopens a closed tab or window, the most recently closed one when sessionId is empty.
+func (c *Client) RestoreSession(ctx context.Context, sessionId string) (*Session, error) {
+	msg := map[string]any{
+		"command": "session.restore",
+	}
+
+	if sessionId != "" {
+		msg["sessionId"] = sessionId
+	}
+
+	var session Session
+	if err := c.sendJSON(ctx, msg, &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
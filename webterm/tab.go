@@ -0,0 +1,371 @@
+package webterm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+)
+
+type Tab struct {
+	Active          bool    `json:"active"`
+	Audible         bool    `json:"audible"`
+	AutoDiscardable bool    `json:"autoDiscardable"`
+	Discarded       bool    `json:"discarded"`
+	FavIconURL      string  `json:"favIconUrl"`
+	GroupID         int     `json:"groupId"`
+	Height          int     `json:"height"`
+	Highlighted     bool    `json:"highlighted"`
+	ID              int     `json:"id"`
+	Incognito       bool    `json:"incognito"`
+	Index           int     `json:"index"`
+	LastAccessed    float64 `json:"lastAccessed"`
+	MutedInfo       struct {
+		Muted bool `json:"muted"`
+	} `json:"mutedInfo"`
+	Pinned   bool `json:"pinned"`
+	Selected bool `json:"selected"`
+	// SessionID is only set on the closed tabs returned by RecentlyClosed.
+	SessionID string `json:"sessionId,omitempty"`
+	Status    string `json:"status"`
+	Title     string `json:"title"`
+	URL       string `json:"url"`
+	Width     int    `json:"width"`
+	WindowID  int    `json:"windowId"`
+}
+
+// DecodeTabs decodes a list of tabs, also accepting an object of tabs keyed by id
+// as returned by some host implementations.
+func DecodeTabs(res []byte) ([]Tab, error) {
+	var tabs []Tab
+	arrayErr := json.Unmarshal(res, &tabs)
+	if arrayErr == nil {
+		return tabs, nil
+	}
+
+	var tabMap map[string]Tab
+	if err := json.Unmarshal(res, &tabMap); err != nil {
+		return nil, &DecodeError{Err: arrayErr, Body: res}
+	}
+
+	tabs = make([]Tab, 0, len(tabMap))
+	for _, tab := range tabMap {
+		tabs = append(tabs, tab)
+	}
+
+	// map order is random, restore the visual order of the tabs
+	sort.SliceStable(tabs, func(i, j int) bool {
+		if tabs[i].WindowID != tabs[j].WindowID {
+			return tabs[i].WindowID < tabs[j].WindowID
+		}
+		return tabs[i].Index < tabs[j].Index
+	})
+
+	return tabs, nil
+}
+
+func (c *Client) sendTabs(ctx context.Context, payload any) ([]Tab, error) {
+	res, err := c.Send(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeTabs(res)
+}
+
+// ListTabs lists the tabs of the current window.
+func (c *Client) ListTabs(ctx context.Context) ([]Tab, error) {
+	return c.sendTabs(ctx, map[string]any{
+		"command": "tab.list",
+	})
+}
+
+// ListWindowTabs lists the tabs of the given window.
+func (c *Client) ListWindowTabs(ctx context.Context, windowId int) ([]Tab, error) {
+	return c.sendTabs(ctx, map[string]any{
+		"command":  "tab.list",
+		"windowId": windowId,
+	})
+}
+
+// ListAllTabs lists the tabs of every window.
+func (c *Client) ListAllTabs(ctx context.Context) ([]Tab, error) {
+	return c.sendTabs(ctx, map[string]any{
+		"command":    "tab.list",
+		"allWindows": true,
+	})
+}
+
+// TabQuery are the properties matched by QueryTabs, as in chrome.tabs.query. Unset fields match every tab.
+type TabQuery struct {
+	Active  *bool `json:"active,omitempty"`
+	Pinned  *bool `json:"pinned,omitempty"`
+	Audible *bool `json:"audible,omitempty"`
+	Muted   *bool `json:"muted,omitempty"`
+	// URL are match patterns, a tab matching any of them is returned.
+	URL      []string `json:"url,omitempty"`
+	WindowID int      `json:"windowId,omitempty"`
+	// Status is one of loading, complete or unloaded.
+	Status        string `json:"status,omitempty"`
+	CurrentWindow bool   `json:"currentWindow,omitempty"`
+	// GroupID matches the tabs of a group, TabGroupNone the tabs outside of any group.
+	GroupID int `json:"groupId,omitempty"`
+}
+
+// QueryTabs lists the tabs of every window matching the query, the filtering being done by the browser.
+func (c *Client) QueryTabs(ctx context.Context, query TabQuery) ([]Tab, error) {
+	return c.sendTabs(ctx, map[string]any{
+		"command":   "tab.query",
+		"queryInfo": query,
+	})
+}
+
+// GetTab returns the tab with the given id.
+func (c *Client) GetTab(ctx context.Context, tabId int) (*Tab, error) {
+	var tab Tab
+	if err := c.sendJSON(ctx, map[string]any{
+		"command": "tab.get",
+		"tabId":   tabId,
+	}, &tab); err != nil {
+		return nil, err
+	}
+
+	return &tab, nil
+}
+
+// GetTabs returns the tabs with the given ids, in the same order.
+func (c *Client) GetTabs(ctx context.Context, tabIds ...int) ([]Tab, error) {
+	return c.sendTabs(ctx, map[string]any{
+		"command": "tab.get",
+		"tabIds":  tabIds,
+	})
+}
+
+// DuplicateTab opens a copy of a tab next to it and returns the new tab.
+func (c *Client) DuplicateTab(ctx context.Context, tabId int) (*Tab, error) {
+	var tab Tab
+	if err := c.sendJSON(ctx, map[string]any{
+		"command": "tab.duplicate",
+		"tabId":   tabId,
+	}, &tab); err != nil {
+		return nil, err
+	}
+
+	return &tab, nil
+}
+
+// SetTabTitle changes the title of a page and returns its updated tab.
+func (c *Client) SetTabTitle(ctx context.Context, tabId int, title string) (*Tab, error) {
+	var tab Tab
+	if err := c.sendJSON(ctx, map[string]any{
+		"command": "tab.setTitle",
+		"tabId":   tabId,
+		"title":   title,
+	}, &tab); err != nil {
+		return nil, err
+	}
+
+	return &tab, nil
+}
+
+// GetActiveTab returns the active tab of the current window.
+func (c *Client) GetActiveTab(ctx context.Context) (*Tab, error) {
+	var tab Tab
+	if err := c.sendJSON(ctx, map[string]any{
+		"command": "tab.get",
+	}, &tab); err != nil {
+		return nil, err
+	}
+
+	return &tab, nil
+}
+
+// CreateTabsOptions are the optional settings of CreateTabs.
+type CreateTabsOptions struct {
+	// Inactive opens the tabs in the background.
+	Inactive bool
+	// Pinned pins the created tabs.
+	Pinned bool
+	// WindowID opens the tabs in the given window instead of the current one.
+	WindowID int
+	// CookieStoreID opens the tabs in the given container (firefox only).
+	CookieStoreID string
+	// Incognito opens the tabs in an incognito window, WindowID must then be an incognito window.
+	Incognito bool
+}
+
+// CreateTabs opens a tab for each url, in the current window by default, and returns the created tabs.
+func (c *Client) CreateTabs(ctx context.Context, urls []string, opts *CreateTabsOptions) ([]Tab, error) {
+	msg := map[string]any{
+		"command": "tab.create",
+		"urls":    urls,
+	}
+
+	if opts != nil {
+		if opts.Inactive {
+			msg["active"] = false
+		}
+		if opts.Pinned {
+			msg["pinned"] = true
+		}
+		if opts.WindowID != 0 {
+			msg["windowId"] = opts.WindowID
+		}
+		if opts.CookieStoreID != "" {
+			msg["cookieStoreId"] = opts.CookieStoreID
+		}
+		if opts.Incognito {
+			msg["incognito"] = true
+		}
+	}
+
+	return c.sendTabs(ctx, msg)
+}
+
+// HighlightTabs selects the tabs at the given indexes of a window, the current one when windowId is 0,
+// and returns the highlighted tabs of the window.
+func (c *Client) HighlightTabs(ctx context.Context, windowId int, indexes []int) ([]Tab, error) {
+	msg := map[string]any{
+		"command": "tab.highlight",
+		"tabs":    indexes,
+	}
+
+	if windowId != 0 {
+		msg["windowId"] = windowId
+	}
+
+	return c.sendTabs(ctx, msg)
+}
+
+// tabsCommand sends a command applying to the given tabs, or to the active tab when none are given.
+func (c *Client) tabsCommand(ctx context.Context, command string, tabIds []int) error {
+	msg := map[string]any{
+		"command": command,
+	}
+
+	if len(tabIds) > 0 {
+		msg["tabIds"] = tabIds
+	}
+
+	return c.sendJSON(ctx, msg, nil)
+}
+
+// affectedTabsCommand is a tabsCommand returning the tabs it changed,
+// nil when the extension does not report them, e.g. an older version of it.
+func (c *Client) affectedTabsCommand(ctx context.Context, command string, tabIds []int) ([]Tab, error) {
+	msg := map[string]any{
+		"command": command,
+	}
+
+	if len(tabIds) > 0 {
+		msg["tabIds"] = tabIds
+	}
+
+	res, err := c.Send(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if res := bytes.TrimSpace(res); len(res) == 0 || bytes.Equal(res, []byte("null")) {
+		return nil, nil
+	}
+
+	return DecodeTabs(res)
+}
+
+// CloseTabs closes the given tabs, or the active tab when none are given, and returns the closed tabs.
+func (c *Client) CloseTabs(ctx context.Context, tabIds ...int) ([]Tab, error) {
+	return c.affectedTabsCommand(ctx, "tab.remove", tabIds)
+}
+
+// ReloadTabs reloads the given tabs, or the active tab when none are given.
+// With bypassCache, the browser refetches every resource of the pages.
+func (c *Client) ReloadTabs(ctx context.Context, bypassCache bool, tabIds ...int) error {
+	msg := map[string]any{
+		"command":     "tab.reload",
+		"bypassCache": bypassCache,
+	}
+
+	if len(tabIds) > 0 {
+		msg["tabIds"] = tabIds
+	}
+
+	return c.sendJSON(ctx, msg, nil)
+}
+
+// DiscardTabs unloads the given tabs from memory, or the active tab when none are given, and returns the discarded tabs.
+// A discarded tab may get a new id.
+func (c *Client) DiscardTabs(ctx context.Context, tabIds ...int) ([]Tab, error) {
+	return c.affectedTabsCommand(ctx, "tab.discard", tabIds)
+}
+
+// PinTabs pins the given tabs, or the active tab when none are given, and returns the pinned tabs.
+func (c *Client) PinTabs(ctx context.Context, tabIds ...int) ([]Tab, error) {
+	return c.affectedTabsCommand(ctx, "tab.pin", tabIds)
+}
+
+// UnpinTabs unpins the given tabs, or the active tab when none are given, and returns the unpinned tabs.
+func (c *Client) UnpinTabs(ctx context.Context, tabIds ...int) ([]Tab, error) {
+	return c.affectedTabsCommand(ctx, "tab.unpin", tabIds)
+}
+
+// MuteTabs mutes the given tabs, or the active tab when none are given.
+func (c *Client) MuteTabs(ctx context.Context, tabIds ...int) error {
+	return c.tabsCommand(ctx, "tab.mute", tabIds)
+}
+
+// UnmuteTabs unmutes the given tabs, or the active tab when none are given.
+func (c *Client) UnmuteTabs(ctx context.Context, tabIds ...int) error {
+	return c.tabsCommand(ctx, "tab.unmute", tabIds)
+}
+
+// MoveTabsOptions are the optional settings of MoveTabs.
+type MoveTabsOptions struct {
+	// WindowID moves the tabs to another window, they stay in their window by default.
+	WindowID int
+}
+
+// MoveTabs moves tabs to a position of their window, -1 for the end, and returns the moved tabs.
+func (c *Client) MoveTabs(ctx context.Context, tabIds []int, index int, opts *MoveTabsOptions) ([]Tab, error) {
+	msg := map[string]any{
+		"command": "tab.move",
+		"tabIds":  tabIds,
+		"index":   index,
+	}
+
+	if opts != nil && opts.WindowID != 0 {
+		msg["windowId"] = opts.WindowID
+	}
+
+	return c.sendTabs(ctx, msg)
+}
+
+// IsErrorTab reports whether a tab shows a browser error page, e.g. after a failed navigation.
+func (c *Client) IsErrorTab(ctx context.Context, tabId int) (bool, error) {
+	var isError bool
+	if err := c.sendJSON(ctx, map[string]any{
+		"command": "tab.isError",
+		"tabId":   tabId,
+	}, &isError); err != nil {
+		return false, err
+	}
+
+	return isError, nil
+}
+
+// FocusTab activates a tab and focuses its window.
+func (c *Client) FocusTab(ctx context.Context, tabId int) error {
+	return c.sendJSON(ctx, map[string]any{
+		"command": "tab.focus",
+		"tabId":   tabId,
+	}, nil)
+}
+
+// ActivateTab makes a tab the active one of its window, leaving the window in the background.
+func (c *Client) ActivateTab(ctx context.Context, tabId int) error {
+	return c.sendJSON(ctx, map[string]any{
+		"command": "tab.focus",
+		"tabId":   tabId,
+		"raise":   false,
+	}, nil)
+}
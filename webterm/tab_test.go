@@ -0,0 +1,208 @@
+package webterm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTabMethods(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		response string
+		call     func(c *Client) (any, error)
+		wantSent string
+		want     any
+	}{
+		{
+			name:     "ListTabs",
+			response: `[{"id": 1, "title": "Go"}]`,
+			call: func(c *Client) (any, error) {
+				return c.ListTabs(ctx)
+			},
+			wantSent: `{"command": "tab.list"}`,
+			want:     []Tab{{ID: 1, Title: "Go"}},
+		},
+		{
+			name:     "ListAllTabs",
+			response: `[{"id": 1}, {"id": 2, "windowId": 2}]`,
+			call: func(c *Client) (any, error) {
+				return c.ListAllTabs(ctx)
+			},
+			wantSent: `{"command": "tab.list", "allWindows": true}`,
+			want:     []Tab{{ID: 1}, {ID: 2, WindowID: 2}},
+		},
+		{
+			name:     "QueryTabs",
+			response: `[{"id": 3, "groupId": 7}]`,
+			call: func(c *Client) (any, error) {
+				return c.QueryTabs(ctx, TabQuery{GroupID: 7, URL: []string{"*://go.dev/*"}})
+			},
+			wantSent: `{"command": "tab.query", "queryInfo": {"groupId": 7, "url": ["*://go.dev/*"]}}`,
+			want:     []Tab{{ID: 3, GroupID: 7}},
+		},
+		{
+			name:     "GetTab",
+			response: `{"id": 2, "url": "https://go.dev"}`,
+			call: func(c *Client) (any, error) {
+				return c.GetTab(ctx, 2)
+			},
+			wantSent: `{"command": "tab.get", "tabId": 2}`,
+			want:     &Tab{ID: 2, URL: "https://go.dev"},
+		},
+		{
+			name:     "CreateTabs",
+			response: `[{"id": 4, "pinned": true}]`,
+			call: func(c *Client) (any, error) {
+				return c.CreateTabs(ctx, []string{"https://go.dev"}, &CreateTabsOptions{Inactive: true, Pinned: true, WindowID: 2})
+			},
+			wantSent: `{"command": "tab.create", "urls": ["https://go.dev"], "active": false, "pinned": true, "windowId": 2}`,
+			want:     []Tab{{ID: 4, Pinned: true}},
+		},
+		{
+			name:     "CloseTabs",
+			response: `[{"id": 1}, {"id": 2}]`,
+			call: func(c *Client) (any, error) {
+				return c.CloseTabs(ctx, 1, 2)
+			},
+			wantSent: `{"command": "tab.remove", "tabIds": [1, 2]}`,
+			want:     []Tab{{ID: 1}, {ID: 2}},
+		},
+		{
+			name: "CloseTabs on an extension not reporting them",
+			call: func(c *Client) (any, error) {
+				return c.CloseTabs(ctx)
+			},
+			wantSent: `{"command": "tab.remove"}`,
+			want:     []Tab(nil),
+		},
+		{
+			name:     "DiscardTabs",
+			response: `[{"id": 9, "discarded": true}]`,
+			call: func(c *Client) (any, error) {
+				return c.DiscardTabs(ctx, 5)
+			},
+			wantSent: `{"command": "tab.discard", "tabIds": [5]}`,
+			want:     []Tab{{ID: 9, Discarded: true}},
+		},
+		{
+			name:     "MoveTabs",
+			response: `[{"id": 1, "windowId": 2, "index": 0}]`,
+			call: func(c *Client) (any, error) {
+				return c.MoveTabs(ctx, []int{1}, 0, &MoveTabsOptions{WindowID: 2})
+			},
+			wantSent: `{"command": "tab.move", "tabIds": [1], "index": 0, "windowId": 2}`,
+			want:     []Tab{{ID: 1, WindowID: 2}},
+		},
+		{
+			name:     "IsErrorTab",
+			response: `true`,
+			call: func(c *Client) (any, error) {
+				return c.IsErrorTab(ctx, 3)
+			},
+			wantSent: `{"command": "tab.isError", "tabId": 3}`,
+			want:     true,
+		},
+		{
+			name: "ReloadTabs",
+			call: func(c *Client) (any, error) {
+				return nil, c.ReloadTabs(ctx, true, 3)
+			},
+			wantSent: `{"command": "tab.reload", "tabIds": [3], "bypassCache": true}`,
+		},
+		{
+			name: "ActivateTab",
+			call: func(c *Client) (any, error) {
+				return nil, c.ActivateTab(ctx, 3)
+			},
+			wantSent: `{"command": "tab.focus", "tabId": 3, "raise": false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeTransport{response: tt.response}
+			got, err := tt.call(&Client{Transport: transport})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			transport.assertSent(t, tt.wantSent)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTabMethodsDecodeError(t *testing.T) {
+	transport := &fakeTransport{response: `{"id": "1"}`}
+	client := &Client{Transport: transport}
+
+	_, err := client.GetTab(context.Background(), 1)
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("got error %v, want a DecodeError", err)
+	}
+	if string(decodeErr.Body) != `{"id": "1"}` {
+		t.Errorf("body = %s, want the response", decodeErr.Body)
+	}
+}
+
+func TestDecodeTabs(t *testing.T) {
+	tests := []struct {
+		name    string
+		res     string
+		want    []Tab
+		wantErr bool
+	}{
+		{
+			name: "array",
+			res:  `[{"id": 2, "windowId": 1, "index": 1}, {"id": 1, "windowId": 1, "index": 0}]`,
+			want: []Tab{{ID: 2, WindowID: 1, Index: 1}, {ID: 1, WindowID: 1, Index: 0}},
+		},
+		{
+			name: "object keyed by id, in the order of the windows and tabs",
+			res: `{
+				"7": {"id": 7, "windowId": 2, "index": 0},
+				"3": {"id": 3, "windowId": 1, "index": 1},
+				"5": {"id": 5, "windowId": 1, "index": 0}
+			}`,
+			want: []Tab{{ID: 5, WindowID: 1, Index: 0}, {ID: 3, WindowID: 1, Index: 1}, {ID: 7, WindowID: 2, Index: 0}},
+		},
+		{
+			name: "empty array",
+			res:  `[]`,
+			want: []Tab{},
+		},
+		{
+			name:    "neither",
+			res:     `"tabs"`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeTabs([]byte(tt.res))
+			if tt.wantErr {
+				var decodeErr *DecodeError
+				if !errors.As(err, &decodeErr) {
+					t.Fatalf("got error %v, want a DecodeError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
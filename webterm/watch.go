@@ -0,0 +1,80 @@
+package webterm
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"syscall"
+)
+
+// TabEvent is a change of a tab reported by Watch.
+type TabEvent struct {
+	// Type is one of created, updated, removed or activated.
+	Type     string `json:"type"`
+	TabID    int    `json:"tabId"`
+	WindowID int    `json:"windowId,omitempty"`
+	// Tab is the state of the tab after a created or updated event.
+	Tab *Tab `json:"tab,omitempty"`
+	// ChangeInfo lists the properties changed by an updated event.
+	ChangeInfo map[string]any `json:"changeInfo,omitempty"`
+}
+
+// TabEventTypes are the types of the events reported by Watch.
+var TabEventTypes = []string{"created", "updated", "removed", "activated"}
+
+// Watch calls fn with each tab event until ctx is done, fn returning an error stops watching.
+// The client timeout does not apply, the events are streamed for as long as the context lives.
+func (c *Client) Watch(ctx context.Context, fn func(TabEvent) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://localhost:%d/events", c.Port), nil)
+	if err != nil {
+		return err
+	}
+
+	httpClient := &http.Client{Transport: c.HTTPClient.Transport}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+			return notConnected(err)
+		}
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(res.Body)
+		if res.StatusCode == http.StatusBadGateway {
+			return notConnected(errors.New(string(msg)))
+		}
+		return browserError(string(msg))
+	}
+
+	scanner := bufio.NewScanner(res.Body)
+	// updated events carry the whole tab, which can get large with data urls
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var event TabEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return fmt.Errorf("invalid event: %w", err)
+		}
+
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return notConnected(errors.New("the native host closed the event stream"))
+}
@@ -0,0 +1,98 @@
+package webterm
+
+import (
+	"context"
+)
+
+type Window struct {
+	AlwaysOnTop bool `json:"alwaysOnTop"`
+	Focused     bool `json:"focused"`
+	Height      int  `json:"height"`
+	ID          int  `json:"id"`
+	Incognito   bool `json:"incognito"`
+	Left        int  `json:"left"`
+	// SessionID is only set on the closed windows returned by RecentlyClosed.
+	SessionID string `json:"sessionId,omitempty"`
+	State     string `json:"state"`
+	Top       int    `json:"top"`
+	Type      string `json:"type"`
+	Width     int    `json:"width"`
+	Tabs      []Tab  `json:"tabs,omitempty"`
+}
+
+// ListWindows lists the browser windows.
+func (c *Client) ListWindows(ctx context.Context) ([]Window, error) {
+	var windows []Window
+	if err := c.sendJSON(ctx, map[string]any{
+		"command": "window.list",
+	}, &windows); err != nil {
+		return nil, err
+	}
+
+	return windows, nil
+}
+
+// FocusWindow brings a window to the front.
+func (c *Client) FocusWindow(ctx context.Context, windowId int) error {
+	return c.sendJSON(ctx, map[string]any{
+		"command":  "window.focus",
+		"windowId": windowId,
+	}, nil)
+}
+
+// CreateWindowOptions are the optional settings of CreateWindow.
+type CreateWindowOptions struct {
+	// Incognito opens a private window.
+	Incognito bool
+	// TabID moves an existing tab into the new window, instead of opening a new tab.
+	TabID int
+}
+
+// CreateWindow opens a window with a tab for each url, or an empty tab when none are given.
+func (c *Client) CreateWindow(ctx context.Context, urls []string, opts *CreateWindowOptions) (*Window, error) {
+	msg := map[string]any{
+		"command": "window.create",
+	}
+
+	if len(urls) > 0 {
+		msg["url"] = urls
+	}
+
+	if opts != nil && opts.Incognito {
+		msg["incognito"] = true
+	}
+	if opts != nil && opts.TabID != 0 {
+		msg["tabId"] = opts.TabID
+	}
+
+	var window Window
+	if err := c.sendJSON(ctx, msg, &window); err != nil {
+		return nil, err
+	}
+
+	return &window, nil
+}
+
+// CloseWindow closes a window and all its tabs.
+func (c *Client) CloseWindow(ctx context.Context, windowId int) error {
+	return c.sendJSON(ctx, map[string]any{
+		"command":  "window.remove",
+		"windowId": windowId,
+	}, nil)
+}
+
+// GetFocusedWindow returns the window that has the focus.
+func (c *Client) GetFocusedWindow(ctx context.Context) (*Window, error) {
+	windows, err := c.ListWindows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, window := range windows {
+		if window.Focused {
+			return &windows[i], nil
+		}
+	}
+
+	return nil, NotFoundf("no focused window")
+}